@@ -0,0 +1,222 @@
+/*
+Package analysis finds the market listings priced below their reference price after the fees.
+
+	d, err := analysis.NewDetector(analysis.Config{Reference: analysis.ReferenceInstant, Fraction: 0.9, Net: fees.Net})
+	pages, err := exchange.Items.GetAllItemsFromDmarket(ctx, dmarket.ItemsGame(dmarket.GameCSGO))
+	for candidate := range d.Detect(ctx, pages) {
+		...
+	}
+
+The pages are the stream of Items.GetAllItemsFromDmarket, the mock server or the snapshot saved by SaveSnapshot.
+*/
+package analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/defernest/dmarket-go/dmarket"
+)
+
+// ErrConfig indicates an incorrect Config of NewDetector
+var ErrConfig = errors.New("incorrect detector config")
+
+// Reference is the price of the Object the listing price is compared with
+type Reference string
+
+const (
+	ReferenceRecommendedD3     Reference = "recommended_d3"
+	ReferenceRecommendedD7     Reference = "recommended_d7"
+	ReferenceRecommendedD7Plus Reference = "recommended_d7_plus"
+	ReferenceInstant           Reference = "instant"
+	ReferenceSuggested         Reference = "suggested"
+)
+
+// Valid reports whether the reference is known
+func (r Reference) Valid() bool {
+	switch r {
+	case ReferenceRecommendedD3, ReferenceRecommendedD7, ReferenceRecommendedD7Plus, ReferenceInstant, ReferenceSuggested:
+		return true
+	}
+	return false
+}
+
+// Price returns the USD reference price of the object, zero when Dmarket did not send it
+func (r Reference) Price(object dmarket.Object) dmarket.Cents {
+	switch r {
+	case ReferenceRecommendedD3:
+		return object.RecommendedPrice.D3.Usd
+	case ReferenceRecommendedD7:
+		return object.RecommendedPrice.D7.Usd
+	case ReferenceRecommendedD7Plus:
+		return object.RecommendedPrice.D7Plus.Usd
+	case ReferenceInstant:
+		return object.InstantPrice.Usd
+	case ReferenceSuggested:
+		return object.SuggestedPrice.Usd
+	}
+	return 0
+}
+
+/*
+NetFunc returns the proceeds of selling the object for the price, like dmarket.FeeTable.Net
+
+	Net: dmarket.FeeTable{Default: dmarket.Fee{Fraction: 0.1}}.Net - the same fee for all objects
+*/
+type NetFunc func(object dmarket.Object, price dmarket.Cents) dmarket.Cents
+
+/*
+Config sets the listings flagged by the Detector
+
+	Reference - the reference price, ReferenceRecommendedD7 by default
+	Fraction  - the listing is flagged when its price is below Fraction of the net reference price, from 0 to 1
+	Net       - the proceeds of the resale for the reference price after the fees, required
+	MinMargin - the listing with the lower expected margin is not flagged
+*/
+type Config struct {
+	Reference Reference
+	Fraction  float64
+	Net       NetFunc
+	MinMargin dmarket.Cents
+}
+
+/*
+Candidate is the flagged listing
+
+	Reference - the USD reference price of the object
+	Net       - the proceeds of the resale for the reference price
+	Margin    - the expected margin: Net - Object.Price.Usd
+	Error     - the error of the page, the other fields are empty then
+*/
+type Candidate struct {
+	Object    dmarket.Object
+	Reference dmarket.Cents
+	Net       dmarket.Cents
+	Margin    dmarket.Cents
+	Error     error
+}
+
+// Detector flags the underpriced listings, see Config
+type Detector struct {
+	config Config
+}
+
+// NewDetector create new Detector with the config defaults
+func NewDetector(config Config) (*Detector, error) {
+	if config.Reference == "" {
+		config.Reference = ReferenceRecommendedD7
+	}
+	if !config.Reference.Valid() {
+		return nil, fmt.Errorf("%w: unknown reference %q", ErrConfig, config.Reference)
+	}
+	if config.Fraction <= 0 || config.Fraction > 1 || math.IsNaN(config.Fraction) {
+		return nil, fmt.Errorf("%w: fraction %v => 0 < fraction <= 1", ErrConfig, config.Fraction)
+	}
+	if config.Net == nil {
+		return nil, fmt.Errorf("%w: net function of the fees is required", ErrConfig)
+	}
+	return &Detector{config: config}, nil
+}
+
+// Evaluate returns the candidate of the listing, false when the listing is not flagged
+func (d Detector) Evaluate(object dmarket.Object) (Candidate, bool) {
+	reference := d.config.Reference.Price(object)
+	price := object.Price.Usd
+	if reference <= 0 || price <= 0 {
+		return Candidate{}, false
+	}
+	net := d.config.Net(object, reference)
+	margin := net - price
+	if float64(price) >= d.config.Fraction*float64(net) || margin < d.config.MinMargin {
+		return Candidate{}, false
+	}
+	return Candidate{Object: object, Reference: reference, Net: net, Margin: margin}, true
+}
+
+// evaluate returns the candidates of the page ranked by Rank
+func (d Detector) evaluate(objects []dmarket.Object) []Candidate {
+	var candidates []Candidate
+	for _, object := range objects {
+		if candidate, ok := d.Evaluate(object); ok {
+			candidates = append(candidates, candidate)
+		}
+	}
+	Rank(candidates)
+	return candidates
+}
+
+/*
+Detect evaluates the pages and sends the candidates of every page ranked by the margin to the candidates channel.
+The page error is sent as the Candidate with the Error. The candidates channel is closed when the pages channel
+is closed or ctx is done.
+*/
+func (d Detector) Detect(ctx context.Context, pages <-chan *dmarket.GetItemsResponse) chan Candidate {
+	candidates := make(chan Candidate, 1)
+	go func() {
+		defer close(candidates)
+		for {
+			var page *dmarket.GetItemsResponse
+			var ok bool
+			select {
+			case <-ctx.Done():
+				return
+			case page, ok = <-pages:
+				if !ok {
+					return
+				}
+			}
+			if page == nil {
+				continue
+			}
+			found := d.evaluate(page.Objects)
+			if page.Error != nil {
+				found = []Candidate{{Error: page.Error}}
+			}
+			for _, candidate := range found {
+				select {
+				case <-ctx.Done():
+					return
+				case candidates <- candidate:
+				}
+			}
+		}
+	}()
+	return candidates
+}
+
+/*
+Collect evaluates all pages and returns the candidates ranked by the margin, the listings repeated by the pages
+are evaluated once. The candidates found before the page error are returned with it.
+*/
+func (d Detector) Collect(ctx context.Context, pages <-chan *dmarket.GetItemsResponse) ([]Candidate, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var candidates []Candidate
+	seen := make(map[string]bool)
+	for candidate := range d.Detect(ctx, pages) {
+		if candidate.Error != nil {
+			Rank(candidates)
+			return candidates, candidate.Error
+		}
+		if seen[candidate.Object.ItemID] {
+			continue
+		}
+		seen[candidate.Object.ItemID] = true
+		candidates = append(candidates, candidate)
+	}
+	Rank(candidates)
+	return candidates, ctx.Err()
+}
+
+// Rank sorts the candidates by the margin descending, the equal margins by the price ascending
+func Rank(candidates []Candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Margin != candidates[j].Margin {
+			return candidates[i].Margin > candidates[j].Margin
+		}
+		return candidates[i].Object.Price.Usd < candidates[j].Object.Price.Usd
+	})
+}
@@ -0,0 +1,141 @@
+package analysis
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/defernest/dmarket-go/dmarket"
+
+	"github.com/stretchr/testify/require"
+)
+
+func object(id string, price, instant, d7 dmarket.Cents) dmarket.Object {
+	o := dmarket.Object{ItemID: id, Price: dmarket.Price{Usd: price}, InstantPrice: dmarket.Price{Usd: instant}}
+	o.RecommendedPrice.D7.Usd = d7
+	return o
+}
+
+func pages(responses ...*dmarket.GetItemsResponse) chan *dmarket.GetItemsResponse {
+	ch := make(chan *dmarket.GetItemsResponse, len(responses))
+	for _, r := range responses {
+		ch <- r
+	}
+	close(ch)
+	return ch
+}
+
+// noFee keeps the whole reference price, so the margins of the tests are easy to check
+var noFee = dmarket.FeeTable{}.Net
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(Config{Fraction: 0.8, Net: noFee})
+	require.NoError(t, err)
+	require.Equal(t, ReferenceRecommendedD7, d.config.Reference)
+
+	for _, config := range []Config{{Net: noFee}, {Fraction: 1.5, Net: noFee}, {Fraction: 0.5, Reference: "unknown", Net: noFee}, {Fraction: 0.5}} {
+		_, err = NewDetector(config)
+		require.ErrorIs(t, err, ErrConfig)
+	}
+}
+
+func TestDetector_Evaluate(t *testing.T) {
+	d, err := NewDetector(Config{Reference: ReferenceInstant, Fraction: 0.8, Net: dmarket.FeeTable{Default: dmarket.Fee{Fraction: 0.1, MinAmount: 10}}.Net, MinMargin: 10})
+	require.NoError(t, err)
+	tests := []struct {
+		name   string
+		object dmarket.Object
+		margin dmarket.Cents
+		ok     bool
+	}{
+		{name: "underpriced", object: object("1", 500, 1000, 0), margin: 400, ok: true},
+		{name: "the fee eats the discount", object: object("2", 750, 1000, 0)},
+		{name: "small margin", object: object("3", 50, 66, 0)},
+		{name: "no reference", object: object("4", 500, 0, 1000)},
+		{name: "no price", object: object("5", 0, 1000, 0)},
+		{name: "min fee above the reference", object: object("6", 1, 5, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidate, ok := d.Evaluate(tt.object)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.margin, candidate.Margin)
+			if ok {
+				require.Equal(t, dmarket.Cents(1000), candidate.Reference)
+				require.Equal(t, dmarket.Cents(900), candidate.Net)
+			}
+		})
+	}
+}
+
+func TestDetector_Detect(t *testing.T) {
+	d, err := NewDetector(Config{Fraction: 0.9, Net: noFee})
+	require.NoError(t, err)
+	failure := errors.New("page error")
+	var found []Candidate
+	for candidate := range d.Detect(context.Background(), pages(
+		&dmarket.GetItemsResponse{Objects: []dmarket.Object{object("a", 500, 0, 1000), object("b", 100, 0, 1000), object("c", 1000, 0, 1000)}},
+		&dmarket.GetItemsResponse{Error: failure},
+		&dmarket.GetItemsResponse{Objects: []dmarket.Object{object("d", 10, 0, 2000)}},
+	)) {
+		found = append(found, candidate)
+	}
+	require.Len(t, found, 4)
+	require.Equal(t, []string{"b", "a"}, []string{found[0].Object.ItemID, found[1].Object.ItemID})
+	require.ErrorIs(t, found[2].Error, failure)
+	require.Equal(t, "d", found[3].Object.ItemID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = d.Collect(ctx, make(chan *dmarket.GetItemsResponse))
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestDetector_Collect(t *testing.T) {
+	d, err := NewDetector(Config{Fraction: 0.9, Net: noFee})
+	require.NoError(t, err)
+	candidates, err := d.Collect(context.Background(), pages(
+		&dmarket.GetItemsResponse{Objects: []dmarket.Object{object("a", 500, 0, 1000), object("b", 400, 0, 1000)}},
+		&dmarket.GetItemsResponse{Objects: []dmarket.Object{object("a", 500, 0, 1000), object("c", 100, 0, 2000)}},
+	))
+	require.NoError(t, err)
+	require.Len(t, candidates, 3)
+	require.Equal(t, dmarket.Cents(1900), candidates[0].Margin)
+	require.Equal(t, "b", candidates[1].Object.ItemID)
+
+	candidates, err = d.Collect(context.Background(), pages(
+		&dmarket.GetItemsResponse{Objects: []dmarket.Object{object("a", 500, 0, 1000)}},
+		&dmarket.GetItemsResponse{Error: dmarket.ErrRateLimited},
+	))
+	require.ErrorIs(t, err, dmarket.ErrRateLimited)
+	require.Len(t, candidates, 1)
+}
+
+func TestSnapshot(t *testing.T) {
+	var buf bytes.Buffer
+	saved, err := SaveSnapshot(&buf, pages(
+		&dmarket.GetItemsResponse{Cursor: "1", Objects: []dmarket.Object{object("a", 500, 700, 1000)}, Total: dmarket.Total{Items: 2}},
+		&dmarket.GetItemsResponse{Error: dmarket.ErrRateLimited},
+		&dmarket.GetItemsResponse{Objects: []dmarket.Object{object("b", 100, 0, 1000)}, Total: dmarket.Total{Items: 2}},
+	))
+	require.NoError(t, err)
+	require.Equal(t, 2, saved)
+
+	var loaded []*dmarket.GetItemsResponse
+	for page := range LoadSnapshot(&buf) {
+		loaded = append(loaded, page)
+	}
+	require.Equal(t, []*dmarket.GetItemsResponse{
+		{Cursor: "1", Objects: []dmarket.Object{object("a", 500, 700, 1000)}, Total: dmarket.Total{Items: 2}},
+		{Objects: []dmarket.Object{object("b", 100, 0, 1000)}, Total: dmarket.Total{Items: 2}},
+	}, loaded)
+
+	var broken []*dmarket.GetItemsResponse
+	for page := range LoadSnapshot(strings.NewReader(`{"cursor":"1"}` + "\n{broken")) {
+		broken = append(broken, page)
+	}
+	require.Len(t, broken, 2)
+	require.Error(t, broken[1].Error)
+}
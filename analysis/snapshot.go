@@ -0,0 +1,55 @@
+package analysis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/defernest/dmarket-go/dmarket"
+)
+
+/*
+SaveSnapshot writes the pages received from the channel as JSON lines until the channel is closed,
+so the market scan can be replayed by LoadSnapshot. The pages with the error are not written.
+*/
+func SaveSnapshot(w io.Writer, pages <-chan *dmarket.GetItemsResponse) (saved int, err error) {
+	encoder := json.NewEncoder(w)
+	for page := range pages {
+		if page == nil || page.Error != nil {
+			continue
+		}
+		snapshot := *page
+		if err = encoder.Encode(&snapshot); err != nil {
+			return saved, fmt.Errorf("analysis: save snapshot error: %w", err)
+		}
+		saved++
+	}
+	return saved, nil
+}
+
+/*
+LoadSnapshot reads the pages written by SaveSnapshot and sends them to the pages channel like
+Items.GetAllItemsFromDmarket. The decoding error is sent as the page with the Error.
+The pages channel is closed at the end of the snapshot.
+*/
+func LoadSnapshot(r io.Reader) chan *dmarket.GetItemsResponse {
+	pages := make(chan *dmarket.GetItemsResponse, 1)
+	go func() {
+		defer close(pages)
+		decoder := json.NewDecoder(r)
+		for {
+			page := new(dmarket.GetItemsResponse)
+			err := decoder.Decode(page)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				pages <- &dmarket.GetItemsResponse{Error: fmt.Errorf("analysis: load snapshot error: %w", err)}
+				return
+			}
+			pages <- page
+		}
+	}()
+	return pages
+}
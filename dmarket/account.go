@@ -0,0 +1,145 @@
+package dmarket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const (
+	accountBalance = "/account/v1/balance"
+	accountUser    = "/account/v1/user"
+	customizedFees = "/exchange/v1/customized-fees?"
+)
+
+// Account is a service structure for interacting with dmarket account API endpoints
+type Account struct {
+	client Requester
+}
+
+// NewAccount create new Account endpoint client
+func NewAccount(client Requester) *Account {
+	return &Account{client: client}
+}
+
+// Balance represent the user balance in cents
+type Balance struct {
+	USD                    Cents `json:"usd"`
+	USDAvailableToWithdraw Cents `json:"usdAvailableToWithdraw"`
+	DMC                    Cents `json:"dmc"`
+	DMCAvailableToWithdraw Cents `json:"dmcAvailableToWithdraw"`
+}
+
+type SteamAccount struct {
+	SteamID          string `json:"steamId"`
+	Username         string `json:"username"`
+	IsProfilePrivate bool   `json:"isProfilePrivate"`
+	TradeURL         string `json:"tradeUrl"`
+}
+
+// User represent the Dmarket user profile
+type User struct {
+	ID              string       `json:"id"`
+	Username        string       `json:"username"`
+	Email           string       `json:"email"`
+	IsEmailVerified bool         `json:"isEmailVerified"`
+	CountryCode     string       `json:"countryCode"`
+	PublicKey       string       `json:"publicKey"`
+	Level           int          `json:"level"`
+	SteamAccount    SteamAccount `json:"steamAccount"`
+}
+
+/*
+Fee is the fee for selling an item
+
+	Fraction  - part of the item price taken by Dmarket, like 0.1 for 10%
+	MinAmount - minimal fee amount
+*/
+type Fee struct {
+	Fraction  float64 `json:"fraction,string"`
+	MinAmount Cents   `json:"minAmount"`
+}
+
+// ReducedFee is the customized user fee applied to the item Title until ExpiresAt (unix time)
+type ReducedFee struct {
+	Title     string  `json:"title"`
+	Fraction  float64 `json:"fraction,string"`
+	MaxPrice  Cents   `json:"maxPrice"`
+	ExpiresAt int64   `json:"expiresAt"`
+}
+
+// FeesResponse represent the user fee schedule for a game
+type FeesResponse struct {
+	DefaultFee  Fee          `json:"defaultFee"`
+	ReducedFees []ReducedFee `json:"reducedFees"`
+	Total       int          `json:"total"`
+}
+
+/*
+Balance gets the user USD and DMC balance
+
+https://api.dmarket.com/account/v1/balance
+*/
+func (a Account) Balance(ctx context.Context) (*Balance, error) {
+	resp, err := a.client.GetContext(ctx, accountBalance)
+	if err != nil {
+		return nil, fmt.Errorf("api (account): balance request error: %w", err)
+	}
+	balance := new(Balance)
+	err = decodeResponse(resp, balance)
+	if err != nil {
+		return nil, fmt.Errorf("api (account): balance error: %w", err)
+	}
+	return balance, nil
+}
+
+/*
+User gets the user profile
+
+https://api.dmarket.com/account/v1/user
+*/
+func (a Account) User(ctx context.Context) (*User, error) {
+	resp, err := a.client.GetContext(ctx, accountUser)
+	if err != nil {
+		return nil, fmt.Errorf("api (account): user request error: %w", err)
+	}
+	user := new(User)
+	err = decodeResponse(resp, user)
+	if err != nil {
+		return nil, fmt.Errorf("api (account): user error: %w", err)
+	}
+	return user, nil
+}
+
+/*
+Fees gets the default fee and all customized (reduced) fees of the user for the game
+
+https://api.dmarket.com/exchange/v1/customized-fees?gameId={gameID}&offset={offset}&limit={limit}
+*/
+func (a Account) Fees(ctx context.Context, gameID string) (*FeesResponse, error) {
+	const limit = 100
+	fees := new(FeesResponse)
+	for offset := 0; ; offset += limit {
+		query := url.Values{
+			"gameId": {gameID},
+			"offset": {strconv.Itoa(offset)},
+			"limit":  {strconv.Itoa(limit)},
+		}
+		resp, err := a.client.GetContext(ctx, customizedFees+query.Encode())
+		if err != nil {
+			return nil, fmt.Errorf("api (account): fees request error: %w", err)
+		}
+		var page FeesResponse
+		err = decodeResponse(resp, &page)
+		if err != nil {
+			return nil, fmt.Errorf("api (account): fees error: %w", err)
+		}
+		fees.DefaultFee, fees.Total = page.DefaultFee, page.Total
+		fees.ReducedFees = append(fees.ReducedFees, page.ReducedFees...)
+		// the total is unknown when Dmarket does not report it, then only the short page ends the fees
+		if len(page.ReducedFees) < limit || (page.Total > 0 && len(fees.ReducedFees) >= page.Total) {
+			return fees, nil
+		}
+	}
+}
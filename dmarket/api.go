@@ -1,6 +1,7 @@
 package dmarket
 
 import (
+	"bytes"
 	"context"
 	"crypto/ed25519"
 	"encoding/hex"
@@ -18,57 +19,58 @@ import (
 type defaultClient struct {
 	http                  *http.Client
 	rateLimit             *rate.Limiter
+	limits                *RateLimits
 	baseURL               *url.URL
 	publicKey, privateKey string
+	// timeout is applied to requests which context has no deadline
+	timeout   time.Duration
+	userAgent string
+	now       func() time.Time
+	retry     RetryPolicy
 }
 
 func (c defaultClient) Get(endpoint string) (Response, error) {
-	req, err := http.NewRequest(http.MethodGet, endpoint, http.NoBody)
-	if err != nil {
-		return Response{}, err
-	}
-	return c.Do(req)
+	return c.GetContext(context.Background(), endpoint)
 }
 
 func (c defaultClient) Post(endpoint string, body io.Reader) (Response, error) {
-	req, err := http.NewRequest(http.MethodPost, endpoint, body)
-	if err != nil {
-		return Response{}, err
-	}
-	defer func() {
-		err := req.Body.Close()
-		if err != nil {
-			panic("failed when defer resp body close")
-		}
-	}()
-	return c.Do(req)
+	return c.PostContext(context.Background(), endpoint, body)
 }
 
 func (c defaultClient) Delete(endpoint string, body io.Reader) (Response, error) {
-	req, err := http.NewRequest(http.MethodDelete, endpoint, body)
+	return c.DeleteContext(context.Background(), endpoint, body)
+}
+
+func (c defaultClient) Patch(endpoint string, body io.Reader) (Response, error) {
+	return c.PatchContext(context.Background(), endpoint, body)
+}
+
+func (c defaultClient) GetContext(ctx context.Context, endpoint string) (Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
 	if err != nil {
 		return Response{}, err
 	}
-	defer func() {
-		err := req.Body.Close()
-		if err != nil {
-			panic("failed when defer resp body close")
-		}
-	}()
 	return c.Do(req)
 }
 
-func (c defaultClient) Patch(endpoint string, body io.Reader) (Response, error) {
-	req, err := http.NewRequest(http.MethodPatch, endpoint, body)
+func (c defaultClient) PostContext(ctx context.Context, endpoint string, body io.Reader) (Response, error) {
+	return c.doWithBody(ctx, http.MethodPost, endpoint, body)
+}
+
+func (c defaultClient) DeleteContext(ctx context.Context, endpoint string, body io.Reader) (Response, error) {
+	return c.doWithBody(ctx, http.MethodDelete, endpoint, body)
+}
+
+func (c defaultClient) PatchContext(ctx context.Context, endpoint string, body io.Reader) (Response, error) {
+	return c.doWithBody(ctx, http.MethodPatch, endpoint, body)
+}
+
+func (c defaultClient) doWithBody(ctx context.Context, method, endpoint string, body io.Reader) (Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
 	if err != nil {
 		return Response{}, err
 	}
-	defer func() {
-		err := req.Body.Close()
-		if err != nil {
-			panic("failed when defer resp body close")
-		}
-	}()
+	// Do buffers and closes the body, a nil body is sent empty
 	return c.Do(req)
 }
 
@@ -81,46 +83,142 @@ To make a signature (X-Request-Sign), take the following steps:
     2. After you’ve created a non-signed string with a default concatenation method,
        sign it with ed25519 using you secret key.
     3. Encode the result string with hex
+
+The request body is buffered for the signature and restored, so it can still be sent (or resent with req.GetBody).
 */
 func (c defaultClient) sign(req *http.Request) error {
-	timestamp := strconv.FormatInt(time.Now().UTC().Unix(), 10)
+	timestamp := strconv.FormatInt(c.clock().UTC().Unix(), 10)
 	b, err := hex.DecodeString(c.privateKey)
 	if err != nil {
 		return fmt.Errorf("api: decode private key error: %w", err)
 	}
+	body, err := requestBody(req)
+	if err != nil {
+		return fmt.Errorf("api: read request body error: %w", err)
+	}
 	var privateKey [64]byte
 	copy(privateKey[:], b[:64])
+	msg := req.Method + req.URL.RequestURI() + string(body) + timestamp
 	req.Header.Set("X-Sign-Date", timestamp)
-	req.Header.Set("X-Request-Sign", "dmar ed25519 "+hex.EncodeToString(ed25519.Sign(privateKey[:], []byte(req.Method+req.URL.RequestURI()+timestamp))))
+	req.Header.Set("X-Request-Sign", "dmar ed25519 "+hex.EncodeToString(ed25519.Sign(privateKey[:], []byte(msg))))
 	req.Header.Set("X-Api-Key", c.publicKey)
 	return nil
 }
 
+// clock returns the current time of the client
+func (c defaultClient) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+// requestBody reads the whole request body and replaces it with a rewindable copy
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err = req.Body.Close(); err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.ContentLength = int64(len(body))
+	return body, nil
+}
+
 /*
 Do performs a request to the Dmarket Items API
+
+The request is bound to its context, the client timeout is applied only when the context has no deadline
+and covers all attempts. Responses 429 Too Many Requests and 5xx are retried according to the RetryPolicy,
+Response.Attempts reports how many times the request was sent.
+When the context is done before the next attempt, or its deadline is earlier than the backoff delay,
+the last response is returned with the context error, so the error is not nil when the retries did not run out.
 */
 func (c *defaultClient) Do(req *http.Request) (response Response, errs error) {
-	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
 	defer func() {
 		if err := recover(); err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("unexpected error when Do request - abort!\n\terror: %s", err))
 		}
-		cancel()
 	}()
-	err := c.rateLimit.Wait(ctx)
-	if err != nil {
-		return Response{}, fmt.Errorf("api: request rate limiter error: %w", err)
+	ctx := req.Context()
+	if _, ok := ctx.Deadline(); !ok && c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
 	}
 	req.URL = c.baseURL.ResolveReference(req.URL)
-	err = c.sign(req)
+	// the body is buffered once, so every attempt sends the same bytes
+	if _, err := requestBody(req); err != nil {
+		return Response{}, fmt.Errorf("api: read request body error: %w", err)
+	}
+	maxAttempts := c.retry.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	for attempt := 1; ; attempt++ {
+		response, header, err := c.attempt(ctx, req)
+		response.Attempts = attempt
+		statusCode := response.StatusCode
+		if err != nil {
+			if ctx.Err() != nil {
+				return response, err
+			}
+			statusCode = 0
+		}
+		if attempt >= maxAttempts || !c.retry.retryable(req, statusCode) {
+			return response, err
+		}
+		delay := c.retry.backoff(attempt+1, header, c.clock())
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return response, fmt.Errorf("api: retry (attempt %d) in %s error: %w", attempt, delay, context.DeadlineExceeded)
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return response, fmt.Errorf("api: retry (attempt %d) error: %w", attempt, err)
+		}
+	}
+}
+
+// attempt sends the request once, the header of the response is returned for the retry policy
+func (c *defaultClient) attempt(ctx context.Context, req *http.Request) (response Response, header http.Header, errs error) {
+	req = req.Clone(ctx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return Response{}, nil, fmt.Errorf("api: rewind request body error: %w", err)
+		}
+		req.Body = body
+	}
+	group := EndpointGroupOf(req.URL.Path)
+	if c.rateLimit != nil {
+		if err := c.rateLimit.Wait(ctx); err != nil {
+			return Response{}, nil, fmt.Errorf("api: request rate limiter error: %w", err)
+		}
+	}
+	if c.limits != nil {
+		if err := c.limits.Wait(ctx, group); err != nil {
+			return Response{}, nil, fmt.Errorf("api: request rate limiter (%s) error: %w", group, err)
+		}
+	}
+	err := c.sign(req)
 	if err != nil {
-		return Response{}, fmt.Errorf("api: new request sign error: %w", err)
+		return Response{}, nil, fmt.Errorf("api: new request sign error: %w", err)
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := c.http.Do(req.WithContext(ctx))
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	resp, err := c.http.Do(req)
 	if err != nil {
-		return Response{}, fmt.Errorf("api: client Do request error: %w", err)
+		return Response{}, nil, fmt.Errorf("api: client Do request error: %w", err)
 	}
 	defer func() {
 		if resp.Body != nil {
@@ -129,13 +227,20 @@ func (c *defaultClient) Do(req *http.Request) (response Response, errs error) {
 			}
 		}
 	}()
+	if c.limits != nil {
+		if resp.StatusCode == http.StatusTooManyRequests {
+			c.limits.Throttle(group)
+		} else {
+			c.limits.Recover(group)
+		}
+	}
 	response.Status = resp.Status
 	response.StatusCode = resp.StatusCode
 	response.ContentLength = resp.ContentLength
 	response.Request = resp.Request
 	_, err = response.ReadFrom(resp.Body)
 	if err != nil {
-		return Response{}, fmt.Errorf("api: read responce body error: %w", err)
+		return Response{}, nil, fmt.Errorf("api: read responce body error: %w", err)
 	}
-	return response, nil
+	return response, resp.Header, nil
 }
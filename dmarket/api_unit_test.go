@@ -0,0 +1,59 @@
+package dmarket
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultClient_sign(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	c := defaultClient{publicKey: hex.EncodeToString(public), privateKey: hex.EncodeToString(private)}
+	verify := func(t *testing.T, req *http.Request, body string) {
+		sign, err := hex.DecodeString(strings.TrimPrefix(req.Header.Get("X-Request-Sign"), "dmar ed25519 "))
+		require.NoError(t, err)
+		msg := req.Method + req.URL.RequestURI() + body + req.Header.Get("X-Sign-Date")
+		require.True(t, ed25519.Verify(public, []byte(msg), sign))
+		require.Equal(t, c.publicKey, req.Header.Get("X-Api-Key"))
+	}
+	tests := []struct {
+		method string
+		body   string
+	}{
+		{method: http.MethodGet},
+		{method: http.MethodPost, body: `{"Offers":[]}`},
+		{method: http.MethodDelete, body: `{"Targets":[]}`},
+		{method: http.MethodPatch, body: `{"offers":[]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			var body io.Reader = http.NoBody
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, err := http.NewRequest(tt.method, "https://api.dmarket.com/path?q=1", body)
+			require.NoError(t, err)
+			require.NoError(t, c.sign(req))
+			verify(t, req, tt.body)
+			if tt.body == "" {
+				return
+			}
+			restored, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			require.Equal(t, tt.body, string(restored))
+			rewound, err := req.GetBody()
+			require.NoError(t, err)
+			again, err := io.ReadAll(rewound)
+			require.NoError(t, err)
+			require.Equal(t, tt.body, string(again))
+			require.Equal(t, int64(len(tt.body)), req.ContentLength)
+		})
+	}
+}
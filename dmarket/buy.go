@@ -0,0 +1,115 @@
+package dmarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const offersBuy = "/exchange/v1/offers-buy"
+
+var (
+	// ErrBudgetExceeded indicates that the expected total price of the offers to buy is greater than the budget
+	ErrBudgetExceeded = errors.New("expected total price exceeds the budget")
+	// ErrObjectPrice indicates an object without the valid USD price or offer ID that can not be bought
+	ErrObjectPrice = errors.New("object has no valid offer ID or USD price")
+)
+
+// BuyStatus is an outcome of the offer purchase
+type BuyStatus string
+
+const (
+	BuyStatusBought       BuyStatus = "Bought"
+	BuyStatusPriceChanged BuyStatus = "PriceChanged"
+	BuyStatusAlreadySold  BuyStatus = "AlreadySold"
+	// BuyStatusUnknown is used when Dmarket did not report the offer status
+	BuyStatusUnknown BuyStatus = "Unknown"
+)
+
+// BuyOutcome is the purchase result of the single offer
+type BuyOutcome struct {
+	OfferID string
+	ItemID  string
+	// Price is the expected price of the offer
+	Price  Cents
+	Status BuyStatus
+}
+
+type BuyResponse struct {
+	OrderID        string `json:"orderId"`
+	Status         string `json:"status"`
+	TxID           string `json:"txId"`
+	DmOffersStatus map[string]struct {
+		Status BuyStatus `json:"status"`
+	} `json:"dmOffersStatus"`
+	// Outcomes contains the purchase result for every requested object in the request order
+	Outcomes []BuyOutcome `json:"-"`
+}
+
+type buyOffer struct {
+	OfferID string `json:"offerId"`
+	Price   Money  `json:"price"`
+	Type    string `json:"type"`
+}
+
+// buyOfferType maps the type of the listed object onto the offer type of the buy request
+func buyOfferType(t string) (OfferType, error) {
+	switch OfferType(t) {
+	case "", OfferTypeDmarket:
+		return OfferTypeDmarket, nil
+	case OfferTypeP2P:
+		return OfferTypeP2P, nil
+	}
+	return "", fmt.Errorf("%w [type %q]", ErrIncorrectOfferType, t)
+}
+
+/*
+Buy purchases the market offers of the objects at their expected Object.Price.Usd,
+the offer type is Object.Type (OfferTypeDmarket when it is empty), the other types are refused with ErrIncorrectOfferType
+
+https://api.dmarket.com/exchange/v1/offers-buy
+
+The request is not sent when the expected total price is greater than the budget.
+Dmarket refuses to buy an offer when its price was changed or it was already sold,
+so the result of each object is reported with the BuyResponse.Outcomes.
+*/
+func (e *Exchange) Buy(ctx context.Context, objects []Object, budget Cents) (*BuyResponse, error) {
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("api (buy): buy offers error: %w", ErrEmptyBatch)
+	}
+	offers := make([]buyOffer, 0, len(objects))
+	outcomes := make([]BuyOutcome, 0, len(objects))
+	var total Cents
+	for _, object := range objects {
+		price := object.Price.Usd
+		if price <= 0 || object.Extra.OfferID == "" {
+			return nil, fmt.Errorf("api (buy): buy offers error: %w [item %s offer %q price %s]",
+				ErrObjectPrice, object.ItemID, object.Extra.OfferID, price)
+		}
+		offerType, err := buyOfferType(object.Type)
+		if err != nil {
+			return nil, fmt.Errorf("api (buy): buy offers error: %w [item %s offer %q]", err, object.ItemID, object.Extra.OfferID)
+		}
+		total += price
+		offers = append(offers, buyOffer{OfferID: object.Extra.OfferID, Price: object.Price.USD(), Type: string(offerType)})
+		outcomes = append(outcomes, BuyOutcome{OfferID: object.Extra.OfferID, ItemID: object.ItemID, Price: price})
+	}
+	if total > budget {
+		return nil, fmt.Errorf("api (buy): buy offers error: %w [total %s budget %s]", ErrBudgetExceeded, total, budget)
+	}
+	resp := new(BuyResponse)
+	err := sendJSON(ctx, e.client.PatchContext, offersBuy, struct {
+		Offers []buyOffer `json:"offers"`
+	}{offers}, resp)
+	if err != nil {
+		return nil, fmt.Errorf("api (buy): buy offers error: %w", err)
+	}
+	for i := range outcomes {
+		outcomes[i].Status = BuyStatusUnknown
+		if status, ok := resp.DmOffersStatus[outcomes[i].OfferID]; ok && status.Status != "" {
+			outcomes[i].Status = status.Status
+		}
+	}
+	resp.Outcomes = outcomes
+	return resp, nil
+}
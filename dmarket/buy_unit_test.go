@@ -0,0 +1,79 @@
+package dmarket
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func buyObject(offerID string, price Cents) Object {
+	return Object{ItemID: "item-" + offerID, Price: Price{Usd: price}, Extra: Extra{OfferID: offerID}}
+}
+
+func TestExchange_Buy(t *testing.T) {
+	t.Run("request body and outcomes", func(t *testing.T) {
+		r := &recorder{response: respond(http.StatusOK,
+			`{"orderId":"order","status":"TxPending","dmOffersStatus":{"1":{"status":"Bought"},"2":{"status":"AlreadySold"}}}`)}
+		resp, err := NewExchange(r).Buy(context.Background(), []Object{buyObject("1", 100), buyObject("2", 250), buyObject("3", 50)}, 400)
+		require.NoError(t, err)
+		require.Equal(t, http.MethodPatch, r.method)
+		require.Equal(t, offersBuy, r.endpoint)
+		require.JSONEq(t, `{"offers":[`+
+			`{"offerId":"1","price":{"amount":"100","currency":"USD"},"type":"dmarket"},`+
+			`{"offerId":"2","price":{"amount":"250","currency":"USD"},"type":"dmarket"},`+
+			`{"offerId":"3","price":{"amount":"50","currency":"USD"},"type":"dmarket"}]}`, string(r.body))
+		require.Equal(t, "order", resp.OrderID)
+		require.Equal(t, []BuyOutcome{
+			{OfferID: "1", ItemID: "item-1", Price: 100, Status: BuyStatusBought},
+			{OfferID: "2", ItemID: "item-2", Price: 250, Status: BuyStatusAlreadySold},
+			{OfferID: "3", ItemID: "item-3", Price: 50, Status: BuyStatusUnknown},
+		}, resp.Outcomes)
+	})
+	t.Run("p2p offer", func(t *testing.T) {
+		r := &recorder{response: respond(http.StatusOK, `{"orderId":"order","dmOffersStatus":{"p":{"status":"Bought"}}}`)}
+		p2p := buyObject("p", 300)
+		p2p.Type = string(OfferTypeP2P)
+		resp, err := NewExchange(r).Buy(context.Background(), []Object{p2p, buyObject("d", 100)}, 400)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"offers":[`+
+			`{"offerId":"p","price":{"amount":"300","currency":"USD"},"type":"p2p"},`+
+			`{"offerId":"d","price":{"amount":"100","currency":"USD"},"type":"dmarket"}]}`, string(r.body))
+		require.Equal(t, BuyStatusBought, resp.Outcomes[0].Status)
+	})
+	t.Run("error: budget exceeded", func(t *testing.T) {
+		r := &recorder{}
+		_, err := NewExchange(r).Buy(context.Background(), []Object{buyObject("1", 100), buyObject("2", 250)}, 349)
+		require.ErrorIs(t, err, ErrBudgetExceeded)
+		require.Empty(t, r.endpoint)
+	})
+	t.Run("error: object price", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			object Object
+		}{
+			{name: "negative price", object: buyObject("1", -150)},
+			{name: "zero price", object: buyObject("1", 0)},
+			{name: "no offer", object: buyObject("", 100)},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := NewExchange(&recorder{}).Buy(context.Background(), []Object{tt.object}, 1000)
+				require.ErrorIs(t, err, ErrObjectPrice)
+			})
+		}
+	})
+	t.Run("error: unknown offer type", func(t *testing.T) {
+		r := &recorder{}
+		object := buyObject("1", 100)
+		object.Type = "auction"
+		_, err := NewExchange(r).Buy(context.Background(), []Object{object}, 1000)
+		require.ErrorIs(t, err, ErrIncorrectOfferType)
+		require.Empty(t, r.endpoint)
+	})
+	t.Run("error: empty batch", func(t *testing.T) {
+		_, err := NewExchange(&recorder{}).Buy(context.Background(), nil, 1000)
+		require.ErrorIs(t, err, ErrEmptyBatch)
+	})
+}
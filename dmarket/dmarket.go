@@ -1,6 +1,7 @@
 package dmarket
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -12,7 +13,11 @@ import (
 type Client struct {
 	DefaultClient *defaultClient
 
-	Exchange *Exchange
+	Exchange  *Exchange
+	Account   *Account
+	Market    *Market
+	History   *History
+	Inventory *Inventory
 }
 
 type errorBadKeys struct {
@@ -25,8 +30,127 @@ func (e errorBadKeys) Error() string {
 		"private key: %s len: %d must be 128\n", e.public, len(e.public), e.private, len(e.private))
 }
 
-// NewClient create a new Dmarket API client
-func NewClient(baseURL, publicKey, privateKey string) (*Client, error) {
+// ErrClientOption indicates a wrong option value passed to NewClient
+var ErrClientOption = errors.New("incorrect client option")
+
+// ClientOption is functional option for NewClient
+type ClientOption func(c *defaultClient) error
+
+/*
+WithRequestTimeout sets the timeout of requests which context has no deadline, 5 seconds by default
+
+A deadline of the request context always takes precedence, so slow calls can be given more time per call.
+The default http.Client still limits every request to 10 seconds, set WithHTTPClient for longer calls.
+*/
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(c *defaultClient) error {
+		if timeout <= 0 {
+			return fmt.Errorf("%w: request timeout %s must be greater than zero", ErrClientOption, timeout)
+		}
+		c.timeout = timeout
+		return nil
+	}
+}
+
+/*
+WithHTTPClient sets the http.Client used to send requests, so a custom transport (proxy, mTLS, tracing) can be injected.
+The default client has the 10 seconds timeout.
+*/
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *defaultClient) error {
+		if client == nil {
+			return fmt.Errorf("%w: http client must not be nil", ErrClientOption)
+		}
+		c.http = client
+		return nil
+	}
+}
+
+/*
+WithRateLimit sets the limit of all requests on top of the per group limits (see WithGroupRateLimit),
+5 requests per second with burst 1 by default
+*/
+func WithRateLimit(limit rate.Limit, burst int) ClientOption {
+	return func(c *defaultClient) error {
+		if limit <= 0 || burst < 1 {
+			return fmt.Errorf("%w: rate limit %v burst %d => limit > 0 && burst >= 1", ErrClientOption, limit, burst)
+		}
+		c.rateLimit = rate.NewLimiter(limit, burst)
+		return nil
+	}
+}
+
+/*
+WithGroupRateLimit sets the rate limit of the endpoint group, DefaultGroupRateLimits with burst 1 are used by default
+
+The limit of the group is halved when Dmarket answers 429 Too Many Requests
+and gradually restored after successful responses.
+*/
+func WithGroupRateLimit(group EndpointGroup, limit rate.Limit, burst int) ClientOption {
+	return func(c *defaultClient) error {
+		if limit <= 0 || burst < 1 {
+			return fmt.Errorf("%w: %s rate limit %v burst %d => limit > 0 && burst >= 1", ErrClientOption, group, limit, burst)
+		}
+		c.limits.Set(group, limit, burst)
+		return nil
+	}
+}
+
+/*
+WithRetryPolicy sets the retry policy of 429 Too Many Requests and 5xx responses, DefaultRetryPolicy by default
+
+RetryPolicy{MaxAttempts: 1} disables retries.
+*/
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *defaultClient) error {
+		if policy.MaxAttempts < 1 || policy.BaseDelay < 0 || policy.MaxDelay < policy.BaseDelay {
+			return fmt.Errorf("%w: retry policy %+v => max attempts >= 1 && 0 <= base delay <= max delay",
+				ErrClientOption, policy)
+		}
+		c.retry = policy
+		return nil
+	}
+}
+
+/*
+WithUserAgent sets the User-Agent header of every request
+*/
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *defaultClient) error {
+		if userAgent == "" {
+			return fmt.Errorf("%w: user agent must not be empty", ErrClientOption)
+		}
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+/*
+WithClock sets the source of the current time used for the X-Sign-Date of request signatures, time.Now by default
+*/
+func WithClock(now func() time.Time) ClientOption {
+	return func(c *defaultClient) error {
+		if now == nil {
+			return fmt.Errorf("%w: clock must not be nil", ErrClientOption)
+		}
+		c.now = now
+		return nil
+	}
+}
+
+/*
+NewClient create a new Dmarket API client
+
+Available options:
+	WithRequestTimeout(timeout time.Duration)
+	WithHTTPClient(client *http.Client)
+	WithRateLimit(limit rate.Limit, burst int)
+	WithGroupRateLimit(group EndpointGroup, limit rate.Limit, burst int)
+	WithRetryPolicy(policy RetryPolicy)
+	WithUserAgent(userAgent string)
+	WithClock(now func() time.Time)
+*/
+func NewClient(baseURL, publicKey, privateKey string, options ...ClientOption) (*Client, error) {
 	if len(publicKey) != 64 || len(privateKey) != 128 {
 		return nil, errorBadKeys{public: publicKey, private: privateKey}
 	}
@@ -38,11 +162,25 @@ func NewClient(baseURL, publicKey, privateKey string) (*Client, error) {
 		DefaultClient: &defaultClient{
 			http:       &http.Client{Timeout: 10 * time.Second},
 			rateLimit:  rate.NewLimiter(rate.Every(200*time.Millisecond), 1),
+			limits:     NewRateLimits(),
 			baseURL:    base,
 			publicKey:  publicKey,
 			privateKey: privateKey,
+			timeout:    5 * time.Second,
+			now:        time.Now,
+			retry:      DefaultRetryPolicy(),
 		},
 	}
+	for _, option := range options {
+		if err := option(c.DefaultClient); err != nil {
+			return nil, err
+		}
+	}
 	c.Exchange = NewExchange(c.DefaultClient)
+	c.Account = NewAccount(c.DefaultClient)
+	c.Market = NewMarket(c.DefaultClient)
+	c.History = NewHistory(c.DefaultClient)
+	c.Inventory = NewInventory(c.DefaultClient)
+	c.Inventory.Items = c.Exchange.Items
 	return c, nil
 }
@@ -1,9 +1,12 @@
 package dmarket
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 )
 
 func TestNewClient(t *testing.T) {
@@ -16,12 +19,73 @@ func TestNewClient(t *testing.T) {
 		require.Equal(t, url, apiClient.DefaultClient.baseURL.Scheme+"://"+apiClient.DefaultClient.baseURL.Host)
 		require.Equal(t, publicKey, apiClient.DefaultClient.publicKey)
 		require.Equal(t, privateKey, apiClient.DefaultClient.privateKey)
+		require.NotNil(t, apiClient.Exchange)
+		require.NotNil(t, apiClient.Account)
+		require.NotNil(t, apiClient.Market)
+		require.NotNil(t, apiClient.History)
+		require.NotNil(t, apiClient.Inventory)
+		require.Same(t, apiClient.Exchange.Items, apiClient.Inventory.Items)
 	})
 	t.Run("err: wrong keys len", func(t *testing.T) {
 		_, err := NewClient("client://localhost", "", "")
 		var keyErr errorBadKeys
 		require.ErrorAs(t, err, &keyErr)
 	})
+	t.Run("success: options", func(t *testing.T) {
+		apiClient, err := NewClient(url, publicKey, privateKey, WithRequestTimeout(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, time.Minute, apiClient.DefaultClient.timeout)
+	})
+	t.Run("success: all options", func(t *testing.T) {
+		httpClient := &http.Client{}
+		now := time.Unix(1633697260, 0)
+		apiClient, err := NewClient(url, publicKey, privateKey,
+			WithHTTPClient(httpClient),
+			WithRateLimit(rate.Limit(20), 10),
+			WithGroupRateLimit(GroupMarket, rate.Limit(2), 1),
+			WithRetryPolicy(RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: time.Minute}),
+			WithUserAgent("dmarket-go-test"),
+			WithClock(func() time.Time { return now }),
+		)
+		require.NoError(t, err)
+		require.Same(t, httpClient, apiClient.DefaultClient.http)
+		require.Equal(t, rate.Limit(20), apiClient.DefaultClient.rateLimit.Limit())
+		require.Equal(t, 10, apiClient.DefaultClient.rateLimit.Burst())
+		require.Equal(t, rate.Limit(2), apiClient.DefaultClient.limits.Limit(GroupMarket))
+		require.Equal(t, RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: time.Minute}, apiClient.DefaultClient.retry)
+		require.Equal(t, "dmarket-go-test", apiClient.DefaultClient.userAgent)
+		require.Equal(t, now, apiClient.DefaultClient.now())
+	})
+	t.Run("success: defaults", func(t *testing.T) {
+		apiClient, err := NewClient(url, publicKey, privateKey)
+		require.NoError(t, err)
+		require.Equal(t, 5*time.Second, apiClient.DefaultClient.timeout)
+		require.Equal(t, 10*time.Second, apiClient.DefaultClient.http.Timeout)
+		require.Equal(t, rate.Limit(5), apiClient.DefaultClient.rateLimit.Limit())
+		require.Equal(t, 1, apiClient.DefaultClient.rateLimit.Burst())
+		for group, limit := range DefaultGroupRateLimits() {
+			require.Equal(t, limit, apiClient.DefaultClient.limits.Limit(group))
+		}
+		require.Equal(t, DefaultRetryPolicy(), apiClient.DefaultClient.retry)
+		require.Empty(t, apiClient.DefaultClient.userAgent)
+	})
+	t.Run("err: wrong option", func(t *testing.T) {
+		options := []ClientOption{
+			WithRequestTimeout(0),
+			WithHTTPClient(nil),
+			WithRateLimit(0, 1),
+			WithRateLimit(1, 0),
+			WithGroupRateLimit(GroupTrading, 0, 1),
+			WithRetryPolicy(RetryPolicy{}),
+			WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Second}),
+			WithUserAgent(""),
+			WithClock(nil),
+		}
+		for _, option := range options {
+			_, err := NewClient(url, publicKey, privateKey, option)
+			require.ErrorIs(t, err, ErrClientOption)
+		}
+	})
 	t.Run("err: wrong host url", func(t *testing.T) {
 		_, err := NewClient("", publicKey, privateKey)
 		require.Error(t, err)
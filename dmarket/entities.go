@@ -45,6 +45,15 @@ type Object struct {
 	Type               string           `json:"type" faker:"len=5"`
 }
 
+/*
+Extra represent the game-specific attributes of the Object, fields which the game does not have are empty
+
+	all games - Name, Category, CategoryPath, GameID, OfferID, LinkID, Tradable, Withdrawable, TradeLock
+	GameCSGO  - Exterior (wear), FloatValue, Quality, Stickers, InspectInGame, TagName, ItemType, Collection
+	GameDota2 - Hero, Gems, Rarity, Quality, Type, Growth (inscribed), Class
+	GameTF2   - Quality, Type, Class, Grade, NameColor, BackgroundColor
+	GameRust  - Category, Type, SerialNumber
+*/
 type Extra struct {
 	Ability           string    `json:"ability" faker:"len=10"`
 	BackgroundColor   string    `json:"backgroundColor" faker:"len=5"`
@@ -82,6 +91,7 @@ type Extra struct {
 	Withdrawable      bool      `json:"withdrawable"`
 }
 
+// RecommendedPrice represent the prices recommended by Dmarket from the sales of the last 3, 7 and more than 7 days
 type RecommendedPrice struct {
 	D3     Price `json:"d3"`
 	D7     Price `json:"d7"`
@@ -94,9 +104,31 @@ type OwnerDetails struct {
 	Wallet string `json:"wallet" faker:"uuid_digit"`
 }
 
+// Price represent the item price in cents in both Dmarket currencies
 type Price struct {
-	Dmc string `json:"DMC" faker:"dprice"`
-	Usd string `json:"USD" faker:"dprice"`
+	Dmc Cents `json:"DMC" faker:"dprice"`
+	Usd Cents `json:"USD" faker:"dprice"`
+}
+
+// USD returns the USD price as Money
+func (p Price) USD() Money {
+	return Money{Amount: p.Usd, Currency: CurrencyUSD}
+}
+
+// DMC returns the DMC price as Money
+func (p Price) DMC() Money {
+	return Money{Amount: p.Dmc, Currency: CurrencyDMC}
+}
+
+// In returns the price in the currency, the zero Money of the currency when Dmarket does not price in it
+func (p Price) In(currency Currency) Money {
+	switch currency {
+	case CurrencyUSD:
+		return p.USD()
+	case CurrencyDMC:
+		return p.DMC()
+	}
+	return Money{Currency: currency}
 }
 
 type Gem struct {
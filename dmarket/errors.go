@@ -0,0 +1,123 @@
+package dmarket
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+var (
+	// ErrInsufficientFunds indicates that the user balance is not enough for the operation
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrItemNotFound indicates that the item, asset, offer or target does not exist (or is already sold)
+	ErrItemNotFound = errors.New("item not found")
+	// ErrInvalidSignature indicates that Dmarket rejected the request signature or the API key
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrRateLimited indicates that Dmarket answered 429 Too Many Requests
+	ErrRateLimited = errors.New("rate limited")
+)
+
+// errorCodes maps the error codes reported by Dmarket to the sentinel errors
+var errorCodes = map[string]error{
+	"InsufficientFunds":   ErrInsufficientFunds,
+	"InsufficientBalance": ErrInsufficientFunds,
+	"NotEnoughMoney":      ErrInsufficientFunds,
+	"ItemNotFound":        ErrItemNotFound,
+	"AssetNotFound":       ErrItemNotFound,
+	"OfferNotFound":       ErrItemNotFound,
+	"TargetNotFound":      ErrItemNotFound,
+	"NotFound":            ErrItemNotFound,
+	"InvalidSignature":    ErrInvalidSignature,
+	"Unauthorized":        ErrInvalidSignature,
+	"TooManyRequests":     ErrRateLimited,
+	"RateLimited":         ErrRateLimited,
+}
+
+// statusErrors maps the HTTP statuses that have the only meaning to the sentinel errors
+var statusErrors = map[int]error{
+	http.StatusUnauthorized:    ErrInvalidSignature,
+	http.StatusTooManyRequests: ErrRateLimited,
+}
+
+// sentinel returns the sentinel error of the Dmarket error code or nil
+func sentinel(code string) error {
+	return errorCodes[code]
+}
+
+/*
+NewErrorRepresentation creates the error of the non-2xx response, decoding the Dmarket error body into RuntimeError
+
+The body is not required to be JSON, ErrorRepresentation.Runtime is nil when it can not be decoded.
+*/
+func NewErrorRepresentation(resp Response) ErrorRepresentation {
+	e := ErrorRepresentation{Response: resp}
+	if resp.Body == nil || resp.Body.Len() == 0 {
+		return e
+	}
+	runtime := new(RuntimeError)
+	if err := json.Unmarshal(resp.Body.Bytes(), runtime); err == nil && (runtime.Err != "" || runtime.Message != "") {
+		e.Runtime = runtime
+	}
+	return e
+}
+
+// Unwrap returns the decoded Dmarket error body, so errors.As can find the RuntimeError
+func (e ErrorRepresentation) Unwrap() error {
+	if e.Runtime == nil {
+		return nil
+	}
+	return *e.Runtime
+}
+
+// Is reports whether the Dmarket error is one of ErrInsufficientFunds, ErrItemNotFound, ErrInvalidSignature, ErrRateLimited
+func (e ErrorRepresentation) Is(target error) bool {
+	if err, ok := statusErrors[e.Response.StatusCode]; ok && err == target {
+		return true
+	}
+	return false
+}
+
+// Is reports whether the error code is one of the sentinel errors, see ErrorRepresentation.Is
+func (e RuntimeError) Is(target error) bool {
+	err := sentinel(e.Err)
+	return err != nil && err == target
+}
+
+// UnmarshalJSON accepts both {"error":"NotFound","code":5} and {"code":"NotFound"} error bodies
+func (e *RuntimeError) UnmarshalJSON(data []byte) error {
+	type runtimeError RuntimeError
+	var raw struct {
+		runtimeError
+		Code json.RawMessage `json:"code"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*e = RuntimeError(raw.runtimeError)
+	code := bytes.TrimSpace(raw.Code)
+	if len(code) == 0 || bytes.Equal(code, []byte("null")) {
+		return nil
+	}
+	if n, err := strconv.Atoi(string(code)); err == nil {
+		e.Code = n
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(code, &s); err != nil {
+		return err
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		e.Code = n
+	} else if e.Err == "" {
+		e.Err = s
+	}
+	return nil
+}
+
+// Is reports whether the error code is one of the sentinel errors, see ErrorRepresentation.Is
+func (e MarketplaceError) Is(target error) bool {
+	err := sentinel(e.Code)
+	return err != nil && err == target
+}
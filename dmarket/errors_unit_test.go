@@ -0,0 +1,109 @@
+package dmarket
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewErrorRepresentation(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    int
+		body    string
+		runtime *RuntimeError
+		is      error
+	}{
+		{
+			name: "gRPC error body",
+			code: http.StatusBadRequest,
+			body: `{"error":"InsufficientFunds","code":3,"message":"not enough money","details":[]}`,
+			runtime: &RuntimeError{Err: "InsufficientFunds", Code: 3, Message: "not enough money", Details: []struct {
+				TypeURL string `json:"type_url"`
+				Value   string `json:"value"`
+			}{}},
+			is: ErrInsufficientFunds,
+		},
+		{
+			name:    "string code body",
+			code:    http.StatusNotFound,
+			body:    `{"code":"ItemNotFound","message":"item not found"}`,
+			runtime: &RuntimeError{Err: "ItemNotFound", Message: "item not found"},
+			is:      ErrItemNotFound,
+		},
+		{
+			name: "rate limited without body",
+			code: http.StatusTooManyRequests,
+			is:   ErrRateLimited,
+		},
+		{
+			name: "unauthorized with text body",
+			code: http.StatusUnauthorized,
+			body: "401: Unauthorized",
+			is:   ErrInvalidSignature,
+		},
+		{
+			name: "empty json body",
+			code: http.StatusBadGateway,
+			body: "{}",
+		},
+	}
+	sentinels := []error{ErrInsufficientFunds, ErrItemNotFound, ErrInvalidSignature, ErrRateLimited}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewErrorRepresentation(respond(tt.code, tt.body))
+			require.Equal(t, tt.runtime, err.Runtime)
+			wrapped := fmt.Errorf("api (test): %w", err)
+			for _, sentinel := range sentinels {
+				require.Equal(t, sentinel == tt.is, errors.Is(wrapped, sentinel), sentinel.Error())
+			}
+			var representation ErrorRepresentation
+			require.ErrorAs(t, wrapped, &representation)
+			if tt.runtime != nil {
+				var runtime RuntimeError
+				require.ErrorAs(t, wrapped, &runtime)
+				require.Contains(t, err.Error(), tt.runtime.Message)
+			}
+		})
+	}
+}
+
+func TestRuntimeError_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		body string
+		want RuntimeError
+	}{
+		{body: `{"error":"NotFound","code":5,"message":"m"}`, want: RuntimeError{Err: "NotFound", Code: 5, Message: "m"}},
+		{body: `{"code":"5","message":"m"}`, want: RuntimeError{Code: 5, Message: "m"}},
+		{body: `{"code":"NotFound"}`, want: RuntimeError{Err: "NotFound"}},
+		{body: `{"error":"InvalidSignature","code":null}`, want: RuntimeError{Err: "InvalidSignature"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			var got RuntimeError
+			require.NoError(t, json.Unmarshal([]byte(tt.body), &got))
+			require.Equal(t, tt.want, got)
+		})
+	}
+	var got RuntimeError
+	require.Error(t, json.Unmarshal([]byte(`{"code":true}`), &got))
+}
+
+func TestMarketplaceError_Is(t *testing.T) {
+	err := fmt.Errorf("offer 1: %w", MarketplaceError{Code: "OfferNotFound", Message: "offer not found"})
+	require.ErrorIs(t, err, ErrItemNotFound)
+	require.False(t, errors.Is(err, ErrInsufficientFunds))
+	require.False(t, errors.Is(MarketplaceError{Code: "InvalidPrice"}, ErrItemNotFound))
+}
+
+func Test_decodeResponse(t *testing.T) {
+	var out struct{}
+	require.NoError(t, decodeResponse(Response{StatusCode: http.StatusNoContent, Body: new(bytes.Buffer)}, &out))
+	err := decodeResponse(respond(http.StatusBadRequest, `{"error":"InsufficientFunds","message":"m"}`), &out)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
@@ -1,25 +1,57 @@
 package dmarket
 
 type Exchange struct {
-	Items *Items
+	client Requester
+
+	Items   *Items
+	Offers  *Offers
+	Targets *Targets
 }
 
 /*
 NewExchange create new Exchange endpoint client with default params
+
 	Items{
 		client:        client,
-		priceFrom:     0,
-		priceTo:       1000000,
-		limit:         100,
+		defaults:      ItemsQuery{
+			game:      DefaultGame,
+			currency:  CurrencyUSD,
+			priceFrom: 0,
+			priceTo:   1000000,
+			limit:     100,
+		},
+	}
+	Offers{
+		client:        client,
+	}
+	Targets{
+		client:        client,
 	}
 */
 func NewExchange(client Requester) *Exchange {
 	exchange := &Exchange{
-		Items: &Items{
-			client:    client,
+		client: client,
+		Items:  newItems(client),
+		Offers: &Offers{
+			client: client,
+		},
+		Targets: &Targets{
+			client: client,
+		},
+	}
+	return exchange
+}
+
+// newItems create new Items endpoint client with the defaults of NewExchange
+func newItems(client Requester) *Items {
+	return &Items{
+		client: client,
+		defaults: ItemsQuery{
+			game:      DefaultGame,
+			currency:  CurrencyUSD,
 			priceFrom: 0,
 			priceTo:   1000000,
 			limit:     100,
-		}}
-	return exchange
+		},
+	}
 }
@@ -0,0 +1,155 @@
+package dmarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrFeeUnreachable indicates the net amount that can not be received with the fee fraction of 1 or more
+var ErrFeeUnreachable = errors.New("net amount is unreachable with the fee")
+
+/*
+FeeTable is the fee model of the sales, it is loaded by Account.FeeTable or filled statically for backtests
+
+	Default - the fee of the games which are not in Games
+	Games   - the default fee of the game, Fee.MinAmount is the minimal fee of the reduced fees of the game too
+	Reduced - the customized fees of the game by the item title
+	Now     - the clock of ReducedFee.ExpiresAt, time.Now by default
+*/
+type FeeTable struct {
+	Default Fee                            `json:"default"`
+	Games   map[Game]Fee                   `json:"games"`
+	Reduced map[Game]map[string]ReducedFee `json:"reduced"`
+	Now     func() time.Time               `json:"-"`
+}
+
+// feeRate is the fee fraction applied to the prices up to max, zero max is not limited
+type feeRate struct {
+	fraction float64
+	max      Cents
+}
+
+// game returns the default fee of the object game
+func (t FeeTable) game(object Object) Fee {
+	if fee, ok := t.Games[Game(object.GameID)]; ok {
+		return fee
+	}
+	return t.Default
+}
+
+// reduced returns the customized fee of the object which is not expired
+func (t FeeTable) reduced(object Object) (ReducedFee, bool) {
+	fee, ok := t.Reduced[Game(object.GameID)][object.Title]
+	if !ok {
+		return ReducedFee{}, false
+	}
+	now := time.Now
+	if t.Now != nil {
+		now = t.Now
+	}
+	if fee.ExpiresAt != 0 && now().Unix() >= fee.ExpiresAt {
+		return ReducedFee{}, false
+	}
+	return fee, true
+}
+
+// rates returns the reduced fee rate if any and the default fee rate of the object
+func (t FeeTable) rates(object Object) []feeRate {
+	rates := make([]feeRate, 0, 2)
+	if fee, ok := t.reduced(object); ok {
+		rates = append(rates, feeRate{fraction: fee.Fraction, max: fee.MaxPrice})
+	}
+	return append(rates, feeRate{fraction: t.game(object).Fraction})
+}
+
+/*
+Fee returns the fee of selling the object for the price: the price part of the reduced fee of the title
+when the price is not more than ReducedFee.MaxPrice or of the game fee, but not less than the game Fee.MinAmount
+and not more than the price
+*/
+func (t FeeTable) Fee(object Object, price Cents) Cents {
+	if price <= 0 {
+		return 0
+	}
+	fraction := t.game(object).Fraction
+	if fee, ok := t.reduced(object); ok && (fee.MaxPrice == 0 || price <= fee.MaxPrice) {
+		fraction = fee.Fraction
+	}
+	amount := NewMoney(price, CurrencyUSD).Fraction(fraction).Amount
+	if min := t.game(object).MinAmount; amount < min {
+		amount = min
+	}
+	if amount > price {
+		amount = price
+	}
+	return amount
+}
+
+// Net returns the proceeds of selling the object for the price: price - Fee
+func (t FeeTable) Net(object Object, price Cents) Cents {
+	return price - t.Fee(object, price)
+}
+
+// ListPrice returns the lowest list price of the object which Net is not less than net
+func (t FeeTable) ListPrice(object Object, net Cents) (Cents, error) {
+	if net <= 0 {
+		return 0, nil
+	}
+	best := Cents(-1)
+	for _, rate := range t.rates(object) {
+		if rate.fraction >= 1 {
+			continue
+		}
+		price := Cents(math.Ceil(float64(net) / (1 - rate.fraction)))
+		if min := net + t.game(object).MinAmount; price < min {
+			price = min
+		}
+		// the fee is rounded to cents, so the estimation is corrected by a cent or two
+		for price > net && t.Net(object, price-1) >= net {
+			price--
+		}
+		for i := 0; i < 2 && t.Net(object, price) < net; i++ {
+			price++
+		}
+		if rate.max != 0 && price > rate.max || t.Net(object, price) < net {
+			continue
+		}
+		if best < 0 || price < best {
+			best = price
+		}
+	}
+	if best < 0 {
+		return 0, fmt.Errorf("%w [title %q net %s]", ErrFeeUnreachable, object.Title, net)
+	}
+	return best, nil
+}
+
+/*
+FeeTable loads the default and the customized fees of the games, see Fees.
+The default fee of the first game is the FeeTable.Default.
+*/
+func (a Account) FeeTable(ctx context.Context, games ...Game) (*FeeTable, error) {
+	if len(games) == 0 {
+		return nil, fmt.Errorf("api (account): fee table error: %w", ErrIncorrectGame)
+	}
+	table := &FeeTable{Games: make(map[Game]Fee, len(games)), Reduced: make(map[Game]map[string]ReducedFee, len(games))}
+	for i, game := range games {
+		fees, err := a.Fees(ctx, string(game))
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			table.Default = fees.DefaultFee
+		}
+		table.Games[game] = fees.DefaultFee
+		reduced := make(map[string]ReducedFee, len(fees.ReducedFees))
+		for _, fee := range fees.ReducedFees {
+			reduced[fee.Title] = fee
+		}
+		table.Reduced[game] = reduced
+	}
+	return table, nil
+}
@@ -0,0 +1,122 @@
+package dmarket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func feeTable() FeeTable {
+	return FeeTable{
+		Default: Fee{Fraction: 0.1, MinAmount: 1},
+		Games:   map[Game]Fee{GameCSGO: {Fraction: 0.05, MinAmount: 2}},
+		Reduced: map[Game]map[string]ReducedFee{GameCSGO: {
+			"reduced": {Title: "reduced", Fraction: 0.02, MaxPrice: 10000},
+			"expired": {Title: "expired", Fraction: 0.01, ExpiresAt: 1000},
+		}},
+		Now: func() time.Time { return time.Unix(2000, 0) },
+	}
+}
+
+func feeObject(game Game, title string) Object {
+	return Object{GameID: string(game), Title: title}
+}
+
+func TestFeeTable_Fee(t *testing.T) {
+	table := feeTable()
+	tests := []struct {
+		name   string
+		object Object
+		price  Cents
+		fee    Cents
+	}{
+		{name: "default", object: feeObject(GameDota2, "item"), price: 1000, fee: 100},
+		{name: "game", object: feeObject(GameCSGO, "item"), price: 1000, fee: 50},
+		{name: "game min amount", object: feeObject(GameCSGO, "item"), price: 10, fee: 2},
+		{name: "min amount is not more than the price", object: feeObject(GameCSGO, "item"), price: 1, fee: 1},
+		{name: "reduced", object: feeObject(GameCSGO, "reduced"), price: 1000, fee: 20},
+		{name: "reduced max price", object: feeObject(GameCSGO, "reduced"), price: 20000, fee: 1000},
+		{name: "reduced min amount", object: feeObject(GameCSGO, "reduced"), price: 50, fee: 2},
+		{name: "expired", object: feeObject(GameCSGO, "expired"), price: 1000, fee: 50},
+		{name: "zero price", object: feeObject(GameCSGO, "item"), price: 0, fee: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.fee, table.Fee(tt.object, tt.price))
+			require.Equal(t, tt.price-tt.fee, table.Net(tt.object, tt.price))
+		})
+	}
+}
+
+func TestFeeTable_ListPrice(t *testing.T) {
+	table := feeTable()
+	for _, object := range []Object{
+		feeObject(GameDota2, "item"), feeObject(GameCSGO, "item"), feeObject(GameCSGO, "reduced"), feeObject(GameCSGO, "expired"),
+	} {
+		for net := Cents(1); net < 12000; net += 7 {
+			price, err := table.ListPrice(object, net)
+			require.NoError(t, err)
+			require.GreaterOrEqual(t, table.Net(object, price), net, "%s net %d", object.Title, net)
+			require.Less(t, table.Net(object, price-1), net, "%s net %d", object.Title, net)
+		}
+	}
+	price, err := table.ListPrice(feeObject(GameCSGO, "reduced"), 9800)
+	require.NoError(t, err)
+	require.Equal(t, Cents(10000), price)
+	price, err = table.ListPrice(feeObject(GameCSGO, "reduced"), 9900)
+	require.NoError(t, err)
+	require.Equal(t, Cents(10421), price)
+
+	price, err = table.ListPrice(feeObject(GameCSGO, "item"), 0)
+	require.NoError(t, err)
+	require.Zero(t, price)
+	_, err = FeeTable{Default: Fee{Fraction: 1}}.ListPrice(Object{}, 100)
+	require.ErrorIs(t, err, ErrFeeUnreachable)
+}
+
+// feesPage returns the customized fees response with count reduced fees starting from the title index from
+func feesPage(from, count int, total string) Response {
+	fees := make([]string, count)
+	for i := range fees {
+		fees[i] = fmt.Sprintf(`{"title":"title %d","fraction":"0.02","maxPrice":10000}`, from+i)
+	}
+	return respond(http.StatusOK, `{"defaultFee":{"fraction":"0.1","minAmount":1},"reducedFees":[`+strings.Join(fees, ",")+`]`+total+`}`)
+}
+
+func TestAccount_Fees(t *testing.T) {
+	t.Run("total", func(t *testing.T) {
+		r := &recorder{queue: []Response{feesPage(0, 100, `,"total":200`), feesPage(100, 100, `,"total":200`)}}
+		fees, err := NewAccount(r).Fees(context.Background(), string(GameCSGO))
+		require.NoError(t, err)
+		require.Len(t, fees.ReducedFees, 200)
+		require.Equal(t, 200, fees.Total)
+		require.Contains(t, r.endpoint, "offset=100")
+	})
+	t.Run("no total", func(t *testing.T) {
+		r := &recorder{queue: []Response{feesPage(0, 100, ""), feesPage(100, 100, ""), feesPage(200, 20, "")}}
+		fees, err := NewAccount(r).Fees(context.Background(), string(GameCSGO))
+		require.NoError(t, err)
+		require.Len(t, fees.ReducedFees, 220)
+		require.Equal(t, "title 219", fees.ReducedFees[219].Title)
+		require.Contains(t, r.endpoint, "offset=200")
+	})
+}
+
+func TestAccount_FeeTable(t *testing.T) {
+	r := &recorder{response: respond(http.StatusOK,
+		`{"defaultFee":{"fraction":"0.1","minAmount":1},"reducedFees":[{"title":"reduced","fraction":"0.02","maxPrice":10000}],"total":1}`)}
+	table, err := NewAccount(r).FeeTable(context.Background(), GameCSGO, GameDota2)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(r.endpoint, customizedFees+"gameId="+string(GameDota2)))
+	require.Equal(t, Fee{Fraction: 0.1, MinAmount: 1}, table.Default)
+	require.Len(t, table.Games, 2)
+	require.Equal(t, Cents(20), table.Fee(Object{GameID: string(GameDota2), Title: "reduced"}, 1000))
+
+	_, err = NewAccount(r).FeeTable(context.Background())
+	require.ErrorIs(t, err, ErrIncorrectGame)
+}
@@ -0,0 +1,185 @@
+package dmarket
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrIncorrectOrder indicates an unknown sort field or direction of Items
+	ErrIncorrectOrder = errors.New("incorrect items order")
+	// ErrIncorrectTreeFilters indicates an incorrect value of the market tree filters
+	ErrIncorrectTreeFilters = errors.New("incorrect tree filters")
+	// ErrIncorrectOfferType indicates an unknown offer type of Items
+	ErrIncorrectOfferType = errors.New("incorrect offer type")
+	// ErrIncorrectTitle indicates an empty exact title of Items or an empty title of Market.LastSales
+	ErrIncorrectTitle = errors.New("title must not be empty")
+)
+
+// OrderBy is the field that the market items are sorted by
+type OrderBy string
+
+const (
+	OrderByTitle        OrderBy = "title"
+	OrderByPrice        OrderBy = "price"
+	OrderByDiscount     OrderBy = "discount"
+	OrderByUpdated      OrderBy = "updated"
+	OrderByBestDiscount OrderBy = "best_discount"
+	OrderByBestDeal     OrderBy = "best_deal"
+)
+
+// OrderDir is the direction of the market items sorting
+type OrderDir string
+
+const (
+	OrderAsc  OrderDir = "asc"
+	OrderDesc OrderDir = "desc"
+)
+
+// OfferType is the type of the market offer
+type OfferType string
+
+const (
+	// OfferTypeDmarket is the offer of the item stored by Dmarket, it can be bought instantly
+	OfferTypeDmarket OfferType = "dmarket"
+	// OfferTypeP2P is the offer of the item in the seller Steam inventory
+	OfferTypeP2P OfferType = "p2p"
+)
+
+/*
+TreeFilters narrows the items by the attributes of the market filter tree
+
+	Exterior           - item wear, like "factory new" (GameCSGO)
+	Category           - category path, like "knife" or "rifle"
+	Rarity             - item rarity, like "covert" or "arcana"
+	Phase              - doppler phase, like "phase-2" (GameCSGO)
+	FloatFrom, FloatTo - float value range from 0 to 1 (GameCSGO), ignored when both are zero
+*/
+type TreeFilters struct {
+	Exterior  []string
+	Category  []string
+	Rarity    []string
+	Phase     []string
+	FloatFrom float64
+	FloatTo   float64
+}
+
+// Validate reports an incorrect float range or an empty filter value
+func (f TreeFilters) Validate() error {
+	if f.FloatFrom < 0 || f.FloatTo > 1 || f.FloatTo < f.FloatFrom {
+		return fmt.Errorf("%w [floatFrom %g floatTo %g] => 0 <= floatFrom <= floatTo <= 1",
+			ErrIncorrectTreeFilters, f.FloatFrom, f.FloatTo)
+	}
+	for _, values := range [][]string{f.Exterior, f.Category, f.Rarity, f.Phase} {
+		for _, value := range values {
+			if strings.TrimSpace(value) == "" || strings.ContainsAny(value, ",=") {
+				return fmt.Errorf("%w [value %q] => value must not be empty or contain ',' and '='",
+					ErrIncorrectTreeFilters, value)
+			}
+		}
+	}
+	return nil
+}
+
+/*
+String encodes the filters to the treeFilters query parameter
+
+	exterior[]=factory new,categoryPath[]=knife,floatValueFrom[]=0,floatValueTo[]=0.07
+*/
+func (f TreeFilters) String() string {
+	var filters []string
+	add := func(key string, values []string) {
+		for _, value := range values {
+			filters = append(filters, key+"[]="+value)
+		}
+	}
+	add("exterior", f.Exterior)
+	add("categoryPath", f.Category)
+	add("rarity", f.Rarity)
+	add("phase", f.Phase)
+	if f.FloatFrom != 0 || f.FloatTo != 0 {
+		add("floatValueFrom", []string{strconv.FormatFloat(f.FloatFrom, 'f', -1, 64)})
+		add("floatValueTo", []string{strconv.FormatFloat(f.FloatTo, 'f', -1, 64)})
+	}
+	return strings.Join(filters, ",")
+}
+
+/*
+ItemsOrder sets the sorting of the requested Items
+
+https://api.dmarket.com/exchange/v1/market/items?orderBy={orderBy}&orderDir={orderDir}
+*/
+func ItemsOrder(orderBy OrderBy, orderDir OrderDir) Options {
+	return func(q *ItemsQuery) error {
+		switch orderBy {
+		case OrderByTitle, OrderByPrice, OrderByDiscount, OrderByUpdated, OrderByBestDiscount, OrderByBestDeal:
+		default:
+			return fmt.Errorf("%w [orderBy %q]", ErrIncorrectOrder, orderBy)
+		}
+		if orderDir != OrderAsc && orderDir != OrderDesc {
+			return fmt.Errorf("%w [orderDir %q]", ErrIncorrectOrder, orderDir)
+		}
+		q.orderBy, q.orderDir = orderBy, orderDir
+		return nil
+	}
+}
+
+/*
+ItemsTreeFilters sets the filters of the item attributes
+
+https://api.dmarket.com/exchange/v1/market/items?treeFilters={filters}
+*/
+func ItemsTreeFilters(filters TreeFilters) Options {
+	return func(q *ItemsQuery) error {
+		if err := filters.Validate(); err != nil {
+			return err
+		}
+		q.treeFilters = filters.String()
+		return nil
+	}
+}
+
+/*
+ItemsTypes sets the types of the requested offers, all types by default
+
+https://api.dmarket.com/exchange/v1/market/items?types={types}
+*/
+func ItemsTypes(types ...OfferType) Options {
+	return func(q *ItemsQuery) error {
+		if len(types) == 0 {
+			return fmt.Errorf("%w: no offer types", ErrIncorrectOfferType)
+		}
+		set := make(map[OfferType]bool, len(types))
+		for _, t := range types {
+			if t != OfferTypeDmarket && t != OfferTypeP2P {
+				return fmt.Errorf("%w [type %q]", ErrIncorrectOfferType, t)
+			}
+			set[t] = true
+		}
+		q.types = make([]string, 0, len(set))
+		for t := range set {
+			q.types = append(q.types, string(t))
+		}
+		sort.Strings(q.types)
+		return nil
+	}
+}
+
+/*
+ItemsExactTitle requests only the items with exactly the title instead of the items which titles contain it
+
+https://api.dmarket.com/exchange/v1/market/items?title={title}&exact=true
+*/
+func ItemsExactTitle(title string) Options {
+	return func(q *ItemsQuery) error {
+		if title == "" {
+			return ErrIncorrectTitle
+		}
+		q.title = title
+		q.exact = true
+		return nil
+	}
+}
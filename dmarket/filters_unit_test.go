@@ -0,0 +1,104 @@
+package dmarket
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemsOrder(t *testing.T) {
+	i := ItemsQuery{}
+	require.NoError(t, ItemsOrder(OrderByPrice, OrderDesc)(&i))
+	require.Equal(t, OrderByPrice, i.orderBy)
+	require.Equal(t, OrderDesc, i.orderDir)
+	require.ErrorIs(t, ItemsOrder("popularity", OrderAsc)(&ItemsQuery{}), ErrIncorrectOrder)
+	require.ErrorIs(t, ItemsOrder(OrderByTitle, "up")(&ItemsQuery{}), ErrIncorrectOrder)
+}
+
+func TestTreeFilters(t *testing.T) {
+	t.Run("encode", func(t *testing.T) {
+		f := TreeFilters{
+			Exterior:  []string{"factory new", "minimal wear"},
+			Category:  []string{"knife"},
+			Rarity:    []string{"covert"},
+			Phase:     []string{"phase-2"},
+			FloatFrom: 0,
+			FloatTo:   0.07,
+		}
+		require.NoError(t, f.Validate())
+		require.Equal(t, "exterior[]=factory new,exterior[]=minimal wear,categoryPath[]=knife,"+
+			"rarity[]=covert,phase[]=phase-2,floatValueFrom[]=0,floatValueTo[]=0.07", f.String())
+		require.Empty(t, TreeFilters{}.String())
+	})
+	t.Run("validate", func(t *testing.T) {
+		for _, f := range []TreeFilters{
+			{FloatFrom: -0.1, FloatTo: 0.5},
+			{FloatFrom: 0.5, FloatTo: 0.1},
+			{FloatTo: 1.5},
+			{Exterior: []string{""}},
+			{Rarity: []string{"covert,classified"}},
+		} {
+			require.ErrorIs(t, f.Validate(), ErrIncorrectTreeFilters)
+			require.ErrorIs(t, ItemsTreeFilters(f)(&ItemsQuery{}), ErrIncorrectTreeFilters)
+		}
+	})
+}
+
+func TestItemsTypes(t *testing.T) {
+	i := ItemsQuery{}
+	require.NoError(t, ItemsTypes(OfferTypeP2P, OfferTypeDmarket, OfferTypeP2P)(&i))
+	require.Equal(t, []string{"dmarket", "p2p"}, i.types)
+	require.ErrorIs(t, ItemsTypes()(&ItemsQuery{}), ErrIncorrectOfferType)
+	require.ErrorIs(t, ItemsTypes("auction")(&ItemsQuery{}), ErrIncorrectOfferType)
+}
+
+func TestItemsExactTitle(t *testing.T) {
+	i := ItemsQuery{}
+	require.NoError(t, ItemsExactTitle("AK-47 | Redline (Field-Tested)")(&i))
+	require.Equal(t, "AK-47 | Redline (Field-Tested)", i.title)
+	require.True(t, i.exact)
+	require.ErrorIs(t, ItemsExactTitle("")(&ItemsQuery{}), ErrIncorrectTitle)
+
+	items := NewExchange(&recorder{}).Items
+	query, err := items.Query(ItemsExactTitle("A"), ItemsTitle("B"))
+	require.NoError(t, err)
+	require.Equal(t, "B", query.title)
+	require.False(t, query.exact)
+	query, err = items.Query(ItemsTitle("A"), ItemsExactTitle("B"))
+	require.NoError(t, err)
+	require.Equal(t, "B", query.title)
+	require.True(t, query.exact)
+}
+
+func TestItems_GetItems_filters(t *testing.T) {
+	r := &recorder{response: respond(http.StatusOK, `{"objects":[],"cursor":""}`)}
+	i := NewExchange(r).Items
+	query, err := i.Query(
+		ItemsOrder(OrderByPrice, OrderAsc),
+		ItemsTreeFilters(TreeFilters{Exterior: []string{"field-tested"}}),
+		ItemsTypes(OfferTypeDmarket),
+		ItemsExactTitle("title"),
+	)
+	require.NoError(t, err)
+	resp := i.GetItems(context.Background(), marketItems, query)
+	require.NoError(t, resp.Error)
+	params, err := url.ParseQuery(strings.TrimPrefix(r.endpoint, marketItems))
+	require.NoError(t, err)
+	require.Equal(t, "price", params.Get("orderBy"))
+	require.Equal(t, "asc", params.Get("orderDir"))
+	require.Equal(t, "exterior[]=field-tested", params.Get("treeFilters"))
+	require.Equal(t, "dmarket", params.Get("types"))
+	require.Equal(t, "title", params.Get("title"))
+	require.Equal(t, "true", params.Get("exact"))
+
+	r.endpoint = ""
+	resp = NewExchange(r).Items.GetItems(context.Background(), marketItems, ItemsQuery{})
+	require.NoError(t, resp.Error)
+	for _, param := range []string{"orderBy", "orderDir", "treeFilters", "types", "exact"} {
+		require.NotContains(t, r.endpoint, param+"=")
+	}
+}
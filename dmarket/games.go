@@ -0,0 +1,72 @@
+package dmarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const games = "/game/v1/games"
+
+// ErrIncorrectGame indicates an empty game ID passed to the Items options
+var ErrIncorrectGame = errors.New("game ID must not be empty")
+
+// Game is the Dmarket game ID, the value of the gameId query parameter and Object.GameID
+type Game string
+
+const (
+	GameCSGO  Game = "a8db"
+	GameDota2 Game = "9a92"
+	GameTF2   Game = "tf2"
+	GameRust  Game = "rust"
+	// DefaultGame is requested by Items when the ItemsGame option is not set
+	DefaultGame = GameDota2
+)
+
+// Games returns the games with the typed constants, the full catalog is available with Exchange.Games
+func Games() []Game {
+	return []Game{GameCSGO, GameDota2, GameTF2, GameRust}
+}
+
+// String returns the game title, like "CS:GO", or the game ID for a game without the typed constant
+func (g Game) String() string {
+	switch g {
+	case GameCSGO:
+		return "CS:GO"
+	case GameDota2:
+		return "Dota 2"
+	case GameTF2:
+		return "Team Fortress 2"
+	case GameRust:
+		return "Rust"
+	}
+	return string(g)
+}
+
+// GameInfo represent the game of the Dmarket catalog
+type GameInfo struct {
+	ID                Game   `json:"gameId"`
+	Title             string `json:"title"`
+	Logo              string `json:"logo"`
+	IsEnabled         bool   `json:"isEnabled"`
+	IsDepositEnabled  bool   `json:"isDepositEnabled"`
+	IsWithdrawEnabled bool   `json:"isWithdrawEnabled"`
+}
+
+/*
+Games gets the games supported by Dmarket, so the catalog can be discovered at runtime
+
+https://api.dmarket.com/game/v1/games
+*/
+func (e *Exchange) Games(ctx context.Context) ([]GameInfo, error) {
+	resp, err := e.client.GetContext(ctx, games)
+	if err != nil {
+		return nil, fmt.Errorf("api (games): games request error: %w", err)
+	}
+	var list []GameInfo
+	err = decodeResponse(resp, &list)
+	if err != nil {
+		return nil, fmt.Errorf("api (games): games error: %w", err)
+	}
+	return list, nil
+}
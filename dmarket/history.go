@@ -0,0 +1,254 @@
+package dmarket
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	closedOffers  = "/marketplace-api/v1/user-offers/closed?"
+	closedTargets = "/marketplace-api/v1/user-targets/closed?"
+)
+
+// ErrHistoryRange indicates an empty or reversed date range of the trade history
+var ErrHistoryRange = errors.New("incorrect history date range")
+
+// TradeKind is the side of the user in the closed trade
+type TradeKind string
+
+const (
+	// TradeSale is the closed offer, the user item is sold
+	TradeSale TradeKind = "sale"
+	// TradePurchase is the closed target, the item is bought by the user
+	TradePurchase TradeKind = "purchase"
+)
+
+/*
+ClosedTrade is the record of the closed offer or target without the counterparty
+
+	ID        - OfferID of the sale or TargetID of the purchase
+	Price     - the final price of the trade
+	Fee       - the fee taken by Dmarket, zero for the purchase
+	Net       - the proceeds of the sale (Price - Fee) or the paid price of the purchase
+	CreatedAt - the offer or target creation time
+	ClosedAt  - the trade time
+*/
+type ClosedTrade struct {
+	Kind      TradeKind `json:"kind"`
+	ID        string    `json:"id"`
+	AssetID   string    `json:"assetId"`
+	Title     string    `json:"title"`
+	Price     Money     `json:"price"`
+	Fee       Money     `json:"fee"`
+	Net       Money     `json:"net"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	ClosedAt  time.Time `json:"closedAt"`
+}
+
+// closedTrade is the closed offer or target sent by Dmarket, the times are sent in unix seconds
+type closedTrade struct {
+	OfferID         string           `json:"OfferID"`
+	TargetID        string           `json:"TargetID"`
+	AssetID         string           `json:"AssetID"`
+	Title           string           `json:"Title"`
+	Price           MarketplacePrice `json:"Price"`
+	Fee             MarketplacePrice `json:"Fee"`
+	Status          string           `json:"Status"`
+	OfferCreatedAt  int64            `json:"OfferCreatedAt,string"`
+	OfferClosedAt   int64            `json:"OfferClosedAt,string"`
+	TargetCreatedAt int64            `json:"TargetCreatedAt,string"`
+	TargetClosedAt  int64            `json:"TargetClosedAt,string"`
+}
+
+type closedTradesResponse struct {
+	Trades []closedTrade `json:"Trades"`
+	Total  int64         `json:"Total,string"`
+	Cursor string        `json:"Cursor"`
+}
+
+// trade converts the closed offer or target into the record
+func (t closedTrade) trade(kind TradeKind) ClosedTrade {
+	currency := Currency(t.Price.Currency)
+	trade := ClosedTrade{
+		Kind:    kind,
+		AssetID: t.AssetID,
+		Title:   t.Title,
+		Price:   NewMoney(Cents(t.Price.Amount), currency),
+		Fee:     NewMoney(0, currency),
+		Status:  t.Status,
+	}
+	switch kind {
+	case TradeSale:
+		trade.ID = t.OfferID
+		trade.Fee.Amount = Cents(t.Fee.Amount)
+		trade.CreatedAt, trade.ClosedAt = time.Unix(t.OfferCreatedAt, 0).UTC(), time.Unix(t.OfferClosedAt, 0).UTC()
+	case TradePurchase:
+		trade.ID = t.TargetID
+		trade.CreatedAt, trade.ClosedAt = time.Unix(t.TargetCreatedAt, 0).UTC(), time.Unix(t.TargetClosedAt, 0).UTC()
+	}
+	// the fee is taken in the currency of the price
+	trade.Net = NewMoney(trade.Price.Amount-trade.Fee.Amount, currency)
+	return trade
+}
+
+// History is a service structure for interacting with dmarket closed offers and targets API endpoints
+type History struct {
+	client Requester
+}
+
+// NewHistory create new History endpoint client
+func NewHistory(client Requester) *History {
+	return &History{client: client}
+}
+
+/*
+HistoryParams sets the game and the date range of the trade history
+
+	From, To - the trades closed from From to To inclusive, both are required
+	Limit    - the page size, 100 by default
+*/
+type HistoryParams struct {
+	Game     Game
+	From, To time.Time
+	Limit    int
+}
+
+func (p HistoryParams) query() (url.Values, error) {
+	if p.Game == "" {
+		return nil, ErrIncorrectGame
+	}
+	if p.From.IsZero() || p.To.IsZero() || p.To.Before(p.From) {
+		return nil, fmt.Errorf("%w [from %s to %s] => from <= to", ErrHistoryRange, p.From, p.To)
+	}
+	if p.Limit < 0 || p.Limit > 100 {
+		return nil, fmt.Errorf("%w [limit %d] => 0 < limit <= 100", ErrLimitPerRequest, p.Limit)
+	}
+	if p.Limit == 0 {
+		p.Limit = 100
+	}
+	return url.Values{
+		"GameID":     {string(p.Game)},
+		"ClosedFrom": {strconv.FormatInt(p.From.Unix(), 10)},
+		"ClosedTo":   {strconv.FormatInt(p.To.Unix(), 10)},
+		"Limit":      {strconv.Itoa(p.Limit)},
+	}, nil
+}
+
+/*
+ClosedOffers gets all user items sold in the date range following the cursor
+
+https://api.dmarket.com/marketplace-api/v1/user-offers/closed?GameID={game}&ClosedFrom={from}&ClosedTo={to}&Limit={limit}&Cursor={cursor}
+*/
+func (h History) ClosedOffers(ctx context.Context, params HistoryParams) ([]ClosedTrade, error) {
+	trades, err := h.closed(ctx, closedOffers, TradeSale, params)
+	if err != nil {
+		return trades, fmt.Errorf("api (history): closed offers error: %w", err)
+	}
+	return trades, nil
+}
+
+/*
+ClosedTargets gets all items bought by the user targets in the date range following the cursor
+
+https://api.dmarket.com/marketplace-api/v1/user-targets/closed?GameID={game}&ClosedFrom={from}&ClosedTo={to}&Limit={limit}&Cursor={cursor}
+*/
+func (h History) ClosedTargets(ctx context.Context, params HistoryParams) ([]ClosedTrade, error) {
+	trades, err := h.closed(ctx, closedTargets, TradePurchase, params)
+	if err != nil {
+		return trades, fmt.Errorf("api (history): closed targets error: %w", err)
+	}
+	return trades, nil
+}
+
+// Trades gets the closed offers and targets in the date range sorted by ClosedAt
+func (h History) Trades(ctx context.Context, params HistoryParams) ([]ClosedTrade, error) {
+	sales, err := h.ClosedOffers(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	purchases, err := h.ClosedTargets(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	trades := append(sales, purchases...)
+	sort.SliceStable(trades, func(i, j int) bool { return trades[i].ClosedAt.Before(trades[j].ClosedAt) })
+	return trades, nil
+}
+
+// closed follows the cursor of the closed trades, the trades received before the error are returned with it
+func (h History) closed(ctx context.Context, endpoint string, kind TradeKind, params HistoryParams) ([]ClosedTrade, error) {
+	query, err := params.query()
+	if err != nil {
+		return nil, err
+	}
+	var trades []ClosedTrade
+	for {
+		resp, err := h.client.GetContext(ctx, endpoint+query.Encode())
+		if err != nil {
+			return trades, fmt.Errorf("request error: %w", err)
+		}
+		var page closedTradesResponse
+		if err = decodeResponse(resp, &page); err != nil {
+			return trades, err
+		}
+		for _, t := range page.Trades {
+			trades = append(trades, t.trade(kind))
+		}
+		if page.Cursor == "" || len(page.Trades) == 0 || page.Cursor == query.Get("Cursor") {
+			return trades, nil
+		}
+		query.Set("Cursor", page.Cursor)
+	}
+}
+
+// tradesCSVHeader is the header of WriteTradesCSV
+var tradesCSVHeader = []string{
+	"kind", "id", "asset_id", "title", "currency", "price", "fee", "net", "status", "created_at", "closed_at",
+}
+
+/*
+WriteTradesCSV writes the trades with the header, the amounts are decimal like 12.34 and the times are RFC 3339
+
+	kind,id,asset_id,title,currency,price,fee,net,status,created_at,closed_at
+*/
+func WriteTradesCSV(w io.Writer, trades []ClosedTrade) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(tradesCSVHeader); err != nil {
+		return fmt.Errorf("trades csv error: %w", err)
+	}
+	for _, t := range trades {
+		record := []string{
+			string(t.Kind), t.ID, t.AssetID, t.Title, string(t.Price.Currency),
+			t.Price.Amount.String(), t.Fee.Amount.String(), t.Net.Amount.String(), t.Status,
+			t.CreatedAt.Format(time.RFC3339), t.ClosedAt.Format(time.RFC3339),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("trades csv error: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("trades csv error: %w", err)
+	}
+	return nil
+}
+
+// WriteTradesJSON writes the trades as a JSON array, the amounts are strings of cents like Dmarket sends them
+func WriteTradesJSON(w io.Writer, trades []ClosedTrade) error {
+	if trades == nil {
+		trades = []ClosedTrade{}
+	}
+	if err := json.NewEncoder(w).Encode(trades); err != nil {
+		return fmt.Errorf("trades json error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package dmarket
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func historyParams() HistoryParams {
+	return HistoryParams{Game: GameCSGO, From: time.Unix(1000, 0), To: time.Unix(2000, 0)}
+}
+
+func TestHistory_ClosedOffers(t *testing.T) {
+	r := &recorder{queue: []Response{
+		respond(http.StatusOK, `{"Trades":[{"OfferID":"o1","AssetID":"a1","Title":"title","Price":{"Currency":"USD","Amount":12.34},`+
+			`"Fee":{"Currency":"USD","Amount":0.62},"Status":"OfferStatusSold","OfferCreatedAt":"1100","OfferClosedAt":"1200"}],"Total":"2","Cursor":"next"}`),
+		respond(http.StatusOK, `{"Trades":[{"OfferID":"o2","Price":{"Currency":"USD","Amount":1},"OfferClosedAt":"1300"}],"Total":"2","Cursor":""}`),
+	}}
+	trades, err := NewHistory(r).ClosedOffers(context.Background(), historyParams())
+	require.NoError(t, err)
+	require.Len(t, trades, 2)
+	require.Equal(t, ClosedTrade{
+		Kind:      TradeSale,
+		ID:        "o1",
+		AssetID:   "a1",
+		Title:     "title",
+		Price:     NewMoney(1234, CurrencyUSD),
+		Fee:       NewMoney(62, CurrencyUSD),
+		Net:       NewMoney(1172, CurrencyUSD),
+		Status:    "OfferStatusSold",
+		CreatedAt: time.Unix(1100, 0).UTC(),
+		ClosedAt:  time.Unix(1200, 0).UTC(),
+	}, trades[0])
+	require.Equal(t, NewMoney(100, CurrencyUSD), trades[1].Net)
+
+	query, err := url.ParseQuery(strings.TrimPrefix(r.endpoint, closedOffers))
+	require.NoError(t, err)
+	require.Equal(t, url.Values{
+		"GameID": {"a8db"}, "ClosedFrom": {"1000"}, "ClosedTo": {"2000"}, "Limit": {"100"}, "Cursor": {"next"},
+	}, query)
+}
+
+func TestHistory_ClosedTargets(t *testing.T) {
+	r := &recorder{response: respond(http.StatusOK, `{"Trades":[{"TargetID":"t1","Title":"title",`+
+		`"Price":{"Currency":"USD","Amount":5},"TargetCreatedAt":"1000","TargetClosedAt":"1500"}],"Total":"1"}`)}
+	trades, err := NewHistory(r).ClosedTargets(context.Background(), historyParams())
+	require.NoError(t, err)
+	require.Equal(t, []ClosedTrade{{
+		Kind:      TradePurchase,
+		ID:        "t1",
+		Title:     "title",
+		Price:     NewMoney(500, CurrencyUSD),
+		Fee:       NewMoney(0, CurrencyUSD),
+		Net:       NewMoney(500, CurrencyUSD),
+		CreatedAt: time.Unix(1000, 0).UTC(),
+		ClosedAt:  time.Unix(1500, 0).UTC(),
+	}}, trades)
+	require.True(t, strings.HasPrefix(r.endpoint, closedTargets))
+}
+
+func TestHistoryParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params func(p *HistoryParams)
+		err    error
+	}{
+		{name: "ERR:no game", params: func(p *HistoryParams) { p.Game = "" }, err: ErrIncorrectGame},
+		{name: "ERR:no from", params: func(p *HistoryParams) { p.From = time.Time{} }, err: ErrHistoryRange},
+		{name: "ERR:to<from", params: func(p *HistoryParams) { p.To = time.Unix(999, 0) }, err: ErrHistoryRange},
+		{name: "ERR:limit>100", params: func(p *HistoryParams) { p.Limit = 101 }, err: ErrLimitPerRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := historyParams()
+			tt.params(&params)
+			r := &recorder{}
+			_, err := NewHistory(r).Trades(context.Background(), params)
+			require.ErrorIs(t, err, tt.err)
+			require.Empty(t, r.endpoint)
+		})
+	}
+}
+
+func TestWriteTrades(t *testing.T) {
+	trades := []ClosedTrade{{
+		Kind:      TradeSale,
+		ID:        "o1",
+		AssetID:   "a1",
+		Title:     `AK-47 | "Redline", FT`,
+		Price:     NewMoney(1234, CurrencyUSD),
+		Fee:       NewMoney(62, CurrencyUSD),
+		Net:       NewMoney(1172, CurrencyUSD),
+		Status:    "OfferStatusSold",
+		CreatedAt: time.Unix(1100, 0).UTC(),
+		ClosedAt:  time.Unix(1200, 0).UTC(),
+	}}
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteTradesCSV(&buf, trades))
+		require.Equal(t, "kind,id,asset_id,title,currency,price,fee,net,status,created_at,closed_at\n"+
+			`sale,o1,a1,"AK-47 | ""Redline"", FT",USD,12.34,0.62,11.72,OfferStatusSold,1970-01-01T00:18:20Z,1970-01-01T00:20:00Z`+"\n",
+			buf.String())
+	})
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteTradesJSON(&buf, trades))
+		require.JSONEq(t, `[{"kind":"sale","id":"o1","assetId":"a1","title":"AK-47 | \"Redline\", FT",`+
+			`"price":{"amount":"1234","currency":"USD"},"fee":{"amount":"62","currency":"USD"},"net":{"amount":"1172","currency":"USD"},`+
+			`"status":"OfferStatusSold","createdAt":"1970-01-01T00:18:20Z","closedAt":"1970-01-01T00:20:00Z"}]`, buf.String())
+		buf.Reset()
+		require.NoError(t, WriteTradesJSON(&buf, nil))
+		require.Equal(t, "[]\n", buf.String())
+	})
+}
@@ -0,0 +1,323 @@
+package dmarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	depositAssets  = "/marketplace-api/v1/deposit-assets"
+	withdrawAssets = "/marketplace-api/v1/withdraw-assets"
+	transferStatus = "/marketplace-api/v1/transfer-status/"
+	syncInventory  = "/marketplace-api/v1/user-inventory/sync"
+	syncStatus     = "/marketplace-api/v1/user-inventory/sync-status?"
+)
+
+var (
+	// ErrTransferAsset indicates the inventory object without the ItemID passed to the transfer
+	ErrTransferAsset = errors.New("transfer asset must have the item ID")
+	// ErrTransferFailed indicates the transfer finished with TransferStatusFailed or TransferStatusCanceled
+	ErrTransferFailed = errors.New("transfer failed")
+	// ErrSyncFailed indicates the inventory sync finished with SyncStatusFailed
+	ErrSyncFailed = errors.New("inventory sync failed")
+	// ErrSyncTimeout indicates the inventory sync not finished after SyncPolicy.MaxAttempts status requests
+	ErrSyncTimeout = errors.New("inventory sync is not finished")
+)
+
+// TransferKind is the direction of the inventory transfer
+type TransferKind string
+
+const (
+	// TransferDeposit moves the items from the Steam inventory to Dmarket
+	TransferDeposit TransferKind = "deposit"
+	// TransferWithdraw moves the items from Dmarket to the Steam inventory
+	TransferWithdraw TransferKind = "withdraw"
+)
+
+// TransferStatus is the status of the inventory transfer
+type TransferStatus string
+
+const (
+	TransferStatusPending    TransferStatus = "TransferStatusPending"
+	TransferStatusInProgress TransferStatus = "TransferStatusInProgress"
+	TransferStatusSuccess    TransferStatus = "TransferStatusSuccess"
+	TransferStatusFailed     TransferStatus = "TransferStatusFailed"
+	TransferStatusCanceled   TransferStatus = "TransferStatusCanceled"
+)
+
+// Terminal reports whether the transfer with the status is finished
+func (s TransferStatus) Terminal() bool {
+	return s == TransferStatusSuccess || s == TransferStatusFailed || s == TransferStatusCanceled
+}
+
+// TransferAsset is the inventory item of the transfer, see AssetOf
+type TransferAsset struct {
+	AssetID string `json:"AssetID"`
+	LinkID  string `json:"LinkID,omitempty"`
+}
+
+// AssetOf returns the transfer asset of the object from GetAllItemsFromUserInventory
+func AssetOf(object Object) TransferAsset {
+	return TransferAsset{AssetID: object.ItemID, LinkID: object.Extra.LinkID}
+}
+
+/*
+Transfer represent the inventory transfer returned by Dmarket
+
+	Error - the reason of TransferStatusFailed or TransferStatusCanceled
+*/
+type Transfer struct {
+	TransferID string          `json:"TransferID"`
+	Kind       TransferKind    `json:"Kind"`
+	Status     TransferStatus  `json:"Status"`
+	Assets     []TransferAsset `json:"Assets"`
+	Error      string          `json:"Error"`
+}
+
+// Err reports the failed or canceled transfer with ErrTransferFailed
+func (t Transfer) Err() error {
+	if t.Status == TransferStatusFailed || t.Status == TransferStatusCanceled {
+		return fmt.Errorf("%w: transfer %s %s: %s", ErrTransferFailed, t.TransferID, t.Status, t.Error)
+	}
+	return nil
+}
+
+// SyncStatus is the status of the inventory sync with Steam
+type SyncStatus string
+
+const (
+	SyncStatusInProgress SyncStatus = "SyncStatusInProgress"
+	SyncStatusDone       SyncStatus = "SyncStatusDone"
+	SyncStatusFailed     SyncStatus = "SyncStatusFailed"
+)
+
+// SyncState represent the inventory sync of the game returned by Dmarket, UpdatedAt is unix time of the last finished sync
+type SyncState struct {
+	GameID    Game       `json:"GameID"`
+	Status    SyncStatus `json:"Status"`
+	UpdatedAt int64      `json:"UpdatedAt,string"`
+	Error     string     `json:"Error"`
+}
+
+/*
+Inventory is a service structure for interacting with dmarket inventory transfer and sync API endpoints
+
+	PollInterval - the interval of the status requests of WaitForTransfer, 2 seconds by default
+	SyncPolicy   - the backoff of the status requests of SyncAndReload, 10 attempts from 1 to 30 seconds by default
+	Items        - the Items service of SyncAndReload, NewClient sets Exchange.Items
+*/
+type Inventory struct {
+	client       Requester
+	PollInterval time.Duration
+	SyncPolicy   RetryPolicy
+	Items        *Items
+}
+
+// NewInventory create new Inventory endpoint client
+func NewInventory(client Requester) *Inventory {
+	return &Inventory{
+		client:       client,
+		PollInterval: 2 * time.Second,
+		SyncPolicy:   RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 30 * time.Second},
+		Items:        newItems(client),
+	}
+}
+
+/*
+Deposit moves the objects of the Steam inventory to Dmarket, the objects are from GetAllItemsFromUserInventory
+
+https://api.dmarket.com/marketplace-api/v1/deposit-assets
+*/
+func (i Inventory) Deposit(ctx context.Context, objects ...Object) (*Transfer, error) {
+	transfer, err := i.transfer(ctx, depositAssets, objects)
+	if err != nil {
+		return nil, fmt.Errorf("api (inventory): deposit error: %w", err)
+	}
+	return transfer, nil
+}
+
+/*
+Withdraw moves the objects of the Dmarket inventory to Steam, the objects are from GetAllItemsFromUserInventory
+
+https://api.dmarket.com/marketplace-api/v1/withdraw-assets
+*/
+func (i Inventory) Withdraw(ctx context.Context, objects ...Object) (*Transfer, error) {
+	transfer, err := i.transfer(ctx, withdrawAssets, objects)
+	if err != nil {
+		return nil, fmt.Errorf("api (inventory): withdraw error: %w", err)
+	}
+	return transfer, nil
+}
+
+func (i Inventory) transfer(ctx context.Context, endpoint string, objects []Object) (*Transfer, error) {
+	if len(objects) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	assets := make([]TransferAsset, 0, len(objects))
+	for _, object := range objects {
+		if object.ItemID == "" {
+			return nil, fmt.Errorf("%w [title %q]", ErrTransferAsset, object.Title)
+		}
+		assets = append(assets, AssetOf(object))
+	}
+	transfer := new(Transfer)
+	err := sendJSON(ctx, i.client.PostContext, endpoint, struct {
+		Assets []TransferAsset `json:"Assets"`
+	}{assets}, transfer)
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+/*
+Transfer gets the transfer status by ID
+
+https://api.dmarket.com/marketplace-api/v1/transfer-status/{transferID}
+*/
+func (i Inventory) Transfer(ctx context.Context, transferID string) (*Transfer, error) {
+	resp, err := i.client.GetContext(ctx, transferStatus+url.PathEscape(transferID))
+	if err != nil {
+		return nil, fmt.Errorf("api (inventory): transfer status request error: %w", err)
+	}
+	transfer := new(Transfer)
+	err = decodeResponse(resp, transfer)
+	if err != nil {
+		return nil, fmt.Errorf("api (inventory): transfer status error: %w", err)
+	}
+	return transfer, nil
+}
+
+/*
+WaitForTransfer polls the transfer status every PollInterval until the transfer is finished or ctx is done.
+
+The failed or canceled transfer is returned with ErrTransferFailed, see Transfer.Err.
+*/
+func (i Inventory) WaitForTransfer(ctx context.Context, transferID string) (*Transfer, error) {
+	interval := i.PollInterval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		transfer, err := i.Transfer(ctx, transferID)
+		if err != nil {
+			return nil, err
+		}
+		if transfer.Status.Terminal() {
+			return transfer, transfer.Err()
+		}
+		select {
+		case <-ctx.Done():
+			return transfer, fmt.Errorf("api (inventory): wait for transfer %s error: %w", transferID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+/*
+Sync triggers the sync of the game inventory with Steam, the sync is finished asynchronously, see SyncStatus
+
+https://api.dmarket.com/marketplace-api/v1/user-inventory/sync
+*/
+func (i Inventory) Sync(ctx context.Context, game Game) (*SyncState, error) {
+	if game == "" {
+		return nil, fmt.Errorf("api (inventory): sync error: %w", ErrIncorrectGame)
+	}
+	state := new(SyncState)
+	err := sendJSON(ctx, i.client.PostContext, syncInventory, struct {
+		Type   string `json:"Type"`
+		GameID Game   `json:"GameID"`
+	}{"Inventory", game}, state)
+	if err != nil {
+		return nil, fmt.Errorf("api (inventory): sync error: %w", err)
+	}
+	return state, nil
+}
+
+/*
+SyncStatus gets the status of the game inventory sync
+
+https://api.dmarket.com/marketplace-api/v1/user-inventory/sync-status?GameID={game}
+*/
+func (i Inventory) SyncStatus(ctx context.Context, game Game) (*SyncState, error) {
+	resp, err := i.client.GetContext(ctx, syncStatus+url.Values{"GameID": {string(game)}}.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("api (inventory): sync status request error: %w", err)
+	}
+	state := new(SyncState)
+	err = decodeResponse(resp, state)
+	if err != nil {
+		return nil, fmt.Errorf("api (inventory): sync status error: %w", err)
+	}
+	return state, nil
+}
+
+/*
+SyncAndReload triggers the sync of the game inventory, waits for it with the SyncPolicy backoff
+and gets all objects of the synced user inventory with Items, see GetAllItemsFromUserInventory options.
+The options are applied after ItemsGame(game).
+
+The sync status is requested before the trigger, the sync is finished when its UpdatedAt moves past
+both the UpdatedAt of that status and the one returned by the trigger,
+so the state of the previous sync reported right after the trigger does not return the stale inventory
+even when the trigger response has no UpdatedAt.
+*/
+func (i Inventory) SyncAndReload(ctx context.Context, game Game, options ...Options) ([]Object, error) {
+	items := i.Items
+	if items == nil {
+		items = newItems(i.client)
+	}
+	pager, err := items.PagerFromUserInventory(append([]Options{ItemsGame(game)}, options...)...)
+	if err != nil {
+		return nil, err
+	}
+	before, err := i.SyncStatus(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	triggered, err := i.Sync(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	since := before.UpdatedAt
+	if triggered.UpdatedAt > since {
+		since = triggered.UpdatedAt
+	}
+	if err = i.waitForSync(ctx, game, since); err != nil {
+		return nil, err
+	}
+	return pager.CollectAll(ctx, 0)
+}
+
+// waitForSync requests the sync status with the SyncPolicy backoff until the sync updated after since is finished
+func (i Inventory) waitForSync(ctx context.Context, game Game, since int64) error {
+	policy := i.SyncPolicy
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	for attempt := 1; ; attempt++ {
+		state, err := i.SyncStatus(ctx, game)
+		if err != nil {
+			return err
+		}
+		switch {
+		case state.UpdatedAt <= since:
+			// the previous sync is reported until the triggered one is started
+		case state.Status == SyncStatusDone:
+			return nil
+		case state.Status == SyncStatusFailed:
+			return fmt.Errorf("api (inventory): %w: game %s: %s", ErrSyncFailed, game, state.Error)
+		}
+		if attempt >= policy.MaxAttempts {
+			return fmt.Errorf("api (inventory): %w: game %s after %d status requests", ErrSyncTimeout, game, attempt)
+		}
+		if err = sleep(ctx, policy.backoff(attempt+1, nil, time.Now())); err != nil {
+			return fmt.Errorf("api (inventory): wait for sync error: %w", err)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package dmarket
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func inventoryObject(itemID, linkID string) Object {
+	return Object{ItemID: itemID, Title: "title", Extra: Extra{LinkID: linkID}}
+}
+
+func TestInventory_Deposit(t *testing.T) {
+	r := &recorder{response: respond(http.StatusOK,
+		`{"TransferID":"d1","Kind":"deposit","Status":"TransferStatusPending","Assets":[{"AssetID":"a1","LinkID":"l1"}]}`)}
+	transfer, err := NewInventory(r).Deposit(context.Background(), inventoryObject("a1", "l1"), inventoryObject("a2", ""))
+	require.NoError(t, err)
+	require.Equal(t, http.MethodPost, r.method)
+	require.Equal(t, depositAssets, r.endpoint)
+	require.JSONEq(t, `{"Assets":[{"AssetID":"a1","LinkID":"l1"},{"AssetID":"a2"}]}`, string(r.body))
+	require.Equal(t, &Transfer{
+		TransferID: "d1",
+		Kind:       TransferDeposit,
+		Status:     TransferStatusPending,
+		Assets:     []TransferAsset{{AssetID: "a1", LinkID: "l1"}},
+	}, transfer)
+
+	_, err = NewInventory(&recorder{}).Withdraw(context.Background())
+	require.ErrorIs(t, err, ErrEmptyBatch)
+	_, err = NewInventory(&recorder{}).Withdraw(context.Background(), inventoryObject("", "l1"))
+	require.ErrorIs(t, err, ErrTransferAsset)
+}
+
+func TestInventory_WaitForTransfer(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		r := &recorder{queue: []Response{
+			respond(http.StatusOK, `{"TransferID":"w1","Status":"TransferStatusPending"}`),
+			respond(http.StatusOK, `{"TransferID":"w1","Status":"TransferStatusInProgress"}`),
+			respond(http.StatusOK, `{"TransferID":"w1","Status":"TransferStatusSuccess"}`),
+		}}
+		inventory := NewInventory(r)
+		inventory.PollInterval = time.Millisecond
+		transfer, err := inventory.WaitForTransfer(context.Background(), "w1")
+		require.NoError(t, err)
+		require.Equal(t, TransferStatusSuccess, transfer.Status)
+		require.Equal(t, transferStatus+"w1", r.endpoint)
+	})
+	t.Run("error: failed", func(t *testing.T) {
+		r := &recorder{response: respond(http.StatusOK, `{"TransferID":"w1","Status":"TransferStatusFailed","Error":"trade ban"}`)}
+		transfer, err := NewInventory(r).WaitForTransfer(context.Background(), "w1")
+		require.ErrorIs(t, err, ErrTransferFailed)
+		require.Contains(t, err.Error(), "trade ban")
+		require.Equal(t, TransferStatusFailed, transfer.Status)
+	})
+	t.Run("error: context", func(t *testing.T) {
+		r := &recorder{response: respond(http.StatusOK, `{"TransferID":"w1","Status":"TransferStatusPending"}`)}
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		inventory := NewInventory(r)
+		inventory.PollInterval = 5 * time.Millisecond
+		transfer, err := inventory.WaitForTransfer(ctx, "w1")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, TransferStatusPending, transfer.Status)
+	})
+	t.Run("error: not found", func(t *testing.T) {
+		r := &recorder{response: respond(http.StatusNotFound, `{"code":"NotFound","message":"transfer not found"}`)}
+		_, err := NewInventory(r).WaitForTransfer(context.Background(), "w1")
+		require.ErrorIs(t, err, ErrItemNotFound)
+	})
+}
+
+func TestTransferStatus_Terminal(t *testing.T) {
+	require.False(t, TransferStatusPending.Terminal())
+	require.False(t, TransferStatusInProgress.Terminal())
+	require.True(t, TransferStatusSuccess.Terminal())
+	require.True(t, TransferStatusFailed.Terminal())
+	require.True(t, TransferStatusCanceled.Terminal())
+}
+
+func TestInventory_Sync(t *testing.T) {
+	r := &recorder{response: respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusInProgress","UpdatedAt":"1600000000"}`)}
+	state, err := NewInventory(r).Sync(context.Background(), GameCSGO)
+	require.NoError(t, err)
+	require.Equal(t, http.MethodPost, r.method)
+	require.Equal(t, syncInventory, r.endpoint)
+	require.JSONEq(t, `{"Type":"Inventory","GameID":"a8db"}`, string(r.body))
+	require.Equal(t, &SyncState{GameID: GameCSGO, Status: SyncStatusInProgress, UpdatedAt: 1600000000}, state)
+
+	_, err = NewInventory(&recorder{}).Sync(context.Background(), "")
+	require.ErrorIs(t, err, ErrIncorrectGame)
+}
+
+func TestInventory_SyncAndReload(t *testing.T) {
+	fast := RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	t.Run("success", func(t *testing.T) {
+		r := &recorder{queue: []Response{
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusDone","UpdatedAt":"100"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusDone","UpdatedAt":"100"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusDone","UpdatedAt":"100"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusInProgress","UpdatedAt":"100"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusDone","UpdatedAt":"200"}`),
+			respond(http.StatusOK, `{"objects":[{"itemId":"fresh"}],"total":{"items":1}}`),
+		}}
+		inventory := NewInventory(r)
+		inventory.SyncPolicy = fast
+		objects, err := inventory.SyncAndReload(context.Background(), GameCSGO, ItemsLimitPerRequest(10))
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		require.Equal(t, "fresh", objects[0].ItemID)
+		require.Equal(t, http.MethodGet, r.method)
+		require.Contains(t, r.endpoint, userItems)
+		require.Contains(t, r.endpoint, "gameId=a8db")
+		require.Contains(t, r.endpoint, "limit=10")
+	})
+	t.Run("error: failed", func(t *testing.T) {
+		r := &recorder{queue: []Response{
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusFailed","UpdatedAt":"100","Error":"previous"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusFailed","UpdatedAt":"100","Error":"previous"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusFailed","UpdatedAt":"100","Error":"previous"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusFailed","UpdatedAt":"200","Error":"private inventory"}`),
+		}}
+		inventory := NewInventory(r)
+		inventory.SyncPolicy = fast
+		_, err := inventory.SyncAndReload(context.Background(), GameCSGO)
+		require.ErrorIs(t, err, ErrSyncFailed)
+		require.Contains(t, err.Error(), "private inventory")
+	})
+	t.Run("trigger without updated at", func(t *testing.T) {
+		r := &recorder{queue: []Response{
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusDone","UpdatedAt":"100"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusInProgress"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusDone","UpdatedAt":"100"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusDone","UpdatedAt":"200"}`),
+			respond(http.StatusOK, `{"objects":[{"itemId":"fresh"}],"total":{"items":1}}`),
+		}}
+		inventory := NewInventory(r)
+		inventory.SyncPolicy = fast
+		objects, err := inventory.SyncAndReload(context.Background(), GameCSGO)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		require.Equal(t, "fresh", objects[0].ItemID)
+
+		r = &recorder{queue: []Response{
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusDone","UpdatedAt":"100"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusInProgress"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusDone","UpdatedAt":"100"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusDone","UpdatedAt":"100"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusDone","UpdatedAt":"100"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusDone","UpdatedAt":"100"}`),
+		}}
+		inventory = NewInventory(r)
+		inventory.SyncPolicy = fast
+		_, err = inventory.SyncAndReload(context.Background(), GameCSGO)
+		require.ErrorIs(t, err, ErrSyncTimeout)
+		require.NotContains(t, r.endpoint, userItems)
+	})
+	t.Run("error: stale done", func(t *testing.T) {
+		r := &recorder{response: respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusDone","UpdatedAt":"100"}`)}
+		inventory := NewInventory(r)
+		inventory.SyncPolicy = fast
+		_, err := inventory.SyncAndReload(context.Background(), GameCSGO)
+		require.ErrorIs(t, err, ErrSyncTimeout)
+		require.NotContains(t, r.endpoint, userItems)
+	})
+	t.Run("items service", func(t *testing.T) {
+		r := &recorder{queue: []Response{
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusDone","UpdatedAt":"100"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusInProgress"}`),
+			respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusDone","UpdatedAt":"200"}`),
+			respond(http.StatusOK, `{"objects":[],"total":{"items":0}}`),
+		}}
+		inventory := NewInventory(r)
+		inventory.SyncPolicy = fast
+		inventory.Items = &Items{client: r, defaults: ItemsQuery{currency: CurrencyDMC, priceTo: 1000000, limit: 25}}
+		_, err := inventory.SyncAndReload(context.Background(), GameCSGO)
+		require.NoError(t, err)
+		require.Contains(t, r.endpoint, "currency=DMC")
+		require.Contains(t, r.endpoint, "limit=25")
+	})
+	t.Run("error: timeout", func(t *testing.T) {
+		r := &recorder{response: respond(http.StatusOK, `{"GameID":"a8db","Status":"SyncStatusInProgress"}`)}
+		inventory := NewInventory(r)
+		inventory.SyncPolicy = fast
+		_, err := inventory.SyncAndReload(context.Background(), GameCSGO)
+		require.ErrorIs(t, err, ErrSyncTimeout)
+		require.Contains(t, r.endpoint, syncStatus+"GameID=a8db")
+	})
+	t.Run("error: options", func(t *testing.T) {
+		r := &recorder{}
+		_, err := NewInventory(r).SyncAndReload(context.Background(), GameCSGO, ItemsLimitPerRequest(1000))
+		require.ErrorIs(t, err, ErrLimitPerRequest)
+		require.Empty(t, r.endpoint)
+	})
+}
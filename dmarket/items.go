@@ -8,6 +8,9 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
 )
 
 const (
@@ -26,13 +29,40 @@ var (
 
 //Items is a service structure for interacting with dmarket Items API endpoint
 type Items struct {
-	client                    Requester
+	client   Requester
+	defaults ItemsQuery
+}
+
+/*
+ItemsQuery is the params of the Items requests with the cursor of the next page.
+The query is immutable: Items.Query applies the options to the copy of the Items defaults
+and WithCursor returns the copy, so one Exchange can run many queries in parallel.
+*/
+type ItemsQuery struct {
+	game                      Game
+	currency                  Currency
 	title, cursor             string
 	priceFrom, priceTo, limit int
+	orderBy                   OrderBy
+	orderDir                  OrderDir
+	treeFilters               string
+	types                     []string
+	exact                     bool
 }
 
-// Options is functional option for Items endpoint
-type Options func(items *Items)
+// Options is functional option for ItemsQuery, it returns an error for a wrong option param
+type Options func(query *ItemsQuery) error
+
+// Cursor returns the cursor of the page requested by the query, empty for the first page
+func (q ItemsQuery) Cursor() string {
+	return q.cursor
+}
+
+// WithCursor returns the copy of the query which requests the page of the cursor
+func (q ItemsQuery) WithCursor(cursor string) ItemsQuery {
+	q.cursor = cursor
+	return q
+}
 
 /*
 ItemsPriceRange sets the exchange price range for request Items
@@ -40,13 +70,14 @@ ItemsPriceRange sets the exchange price range for request Items
 https://api.dmarket.com/exchange/v1/market/items?priceFrom={priceFrom}&priceTo={priceTo}
 */
 func ItemsPriceRange(priceFrom, priceTo int) Options {
-	return func(i *Items) {
+	return func(q *ItemsQuery) error {
 		if priceFrom < 0 || priceTo < priceFrom {
-			panic(fmt.Errorf("%w [priceFrom %d priceTo %d] => priceFrom >= 0 && priceTo > priceFrom",
-				ErrIncorrectPriceRange, priceFrom, priceTo))
+			return fmt.Errorf("%w [priceFrom %d priceTo %d] => priceFrom >= 0 && priceTo > priceFrom",
+				ErrIncorrectPriceRange, priceFrom, priceTo)
 		}
-		i.priceFrom = priceFrom
-		i.priceTo = priceTo
+		q.priceFrom = priceFrom
+		q.priceTo = priceTo
+		return nil
 	}
 }
 
@@ -56,22 +87,55 @@ ItemsLimitPerRequest sets the exchange limit per request for Items
 https://api.dmarket.com/exchange/v1/market/items?limit={limit}
 */
 func ItemsLimitPerRequest(limit int) Options {
-	return func(i *Items) {
+	return func(q *ItemsQuery) error {
 		if limit <= 0 || limit > 100 {
-			panic(ErrLimitPerRequest)
+			return ErrLimitPerRequest
+		}
+		q.limit = limit
+		return nil
+	}
+}
+
+/*
+ItemsGame sets the game of the requested Items, DefaultGame by default
+
+https://api.dmarket.com/exchange/v1/market/items?gameId={game}
+*/
+func ItemsGame(game Game) Options {
+	return func(q *ItemsQuery) error {
+		if game == "" {
+			return ErrIncorrectGame
+		}
+		q.game = game
+		return nil
+	}
+}
+
+/*
+ItemsCurrency sets the currency of the requested Items prices and price range, CurrencyUSD by default
+
+https://api.dmarket.com/exchange/v1/market/items?currency={currency}
+*/
+func ItemsCurrency(currency Currency) Options {
+	return func(q *ItemsQuery) error {
+		if !currency.Valid() {
+			return fmt.Errorf("%w [currency %q]", ErrIncorrectCurrency, currency)
 		}
-		i.limit = limit
+		q.currency = currency
+		return nil
 	}
 }
 
 /*
-ItemsTitle sets the exchange limit per request for Items
+ItemsTitle requests the items which titles contain the title, it overrides ItemsExactTitle passed before
 
 https://api.dmarket.com/exchange/v1/market/items?title={title}
 */
 func ItemsTitle(title string) Options {
-	return func(i *Items) {
-		i.title = title
+	return func(q *ItemsQuery) error {
+		q.title = title
+		q.exact = false
+		return nil
 	}
 }
 
@@ -80,19 +144,26 @@ GetAllItemsFromDmarket
 gets all objects available on the Dmarket exchange with the parameters of the Client's Items and arguments.
 The received response will be sent to the results channel.
 
-If the answer from Dmarket contains an empty slice of schemas.Objects
+If the answer from Dmarket contains an empty slice of schemas.Objects or an empty cursor
 (there are no objects on the market or inventory objects according to the specified filters have already been received),
-but the HTTP response code is 200, will close the result channel.
+but the HTTP response code is 200, will close the result channel. The channel is closed when ctx is done too.
 
 If an error occurs during a request, parsing results, or receiving an HTTP error,
 the error will be sent to the schemas.GetItemsResponse.Errors field, and the channel will be closed.
 
 Available options:
+	ItemsGame(game Game)
+	ItemsCurrency(currency Currency)
+	ItemsOrder(orderBy OrderBy, orderDir OrderDir)
+	ItemsTreeFilters(filters TreeFilters)
+	ItemsTypes(types ...OfferType)
+	ItemsExactTitle(title string)
+	ItemsTitle(title string)
 	ItemsPriceRange(priceFrom, priceTo int)
 	ItemsLimitPerRequest(limit int)
-Panic when options get wrong options params!
+Wrong options params are reported all at once with the returned error, the requests are not sent then.
 */
-func (i Items) GetAllItemsFromDmarket(ctx context.Context, options ...Options) (results chan *GetItemsResponse) {
+func (i Items) GetAllItemsFromDmarket(ctx context.Context, options ...Options) (results chan *GetItemsResponse, err error) {
 	return i.getAllItems(ctx, marketItems, options...)
 }
 
@@ -101,61 +172,109 @@ GetAllItemsFromUserInventory
 gets all objects available on the user inventory with the parameters of the Items client and function arguments.
 The received response will be sent to the results channel.
 
-If the answer from Dmarket contains an empty slice of schemas.Objects
+If the answer from Dmarket contains an empty slice of schemas.Objects or an empty cursor
 (there are no objects on the market or inventory objects according to the specified filters have already been received),
-but the HTTP response code is 200, will close the result channel.
+but the HTTP response code is 200, will close the result channel. The channel is closed when ctx is done too.
 
 If an error occurs during a request, parsing results, or receiving an HTTP error,
 the error will be sent to the schemas.GetItemsResponse.Errors field, and the channel will be closed.
 
 Available options:
+	ItemsGame(game Game)
+	ItemsCurrency(currency Currency)
+	ItemsOrder(orderBy OrderBy, orderDir OrderDir)
+	ItemsTreeFilters(filters TreeFilters)
+	ItemsTypes(types ...OfferType)
+	ItemsExactTitle(title string)
+	ItemsTitle(title string)
 	ItemsPriceRange(priceFrom, priceTo int)
 	ItemsLimitPerRequest(limit int)
-Panic when options get wrong options params!
+Wrong options params are reported all at once with the returned error, the requests are not sent then.
 */
-func (i Items) GetAllItemsFromUserInventory(ctx context.Context, options ...Options) (results chan *GetItemsResponse) {
+func (i Items) GetAllItemsFromUserInventory(ctx context.Context, options ...Options) (results chan *GetItemsResponse, err error) {
 	return i.getAllItems(ctx, userItems, options...)
 }
 
-func (i *Items) getAllItems(ctx context.Context, from string, options ...Options) (results chan *GetItemsResponse) {
-	for _, option := range options {
-		option(i)
+func (i Items) getAllItems(ctx context.Context, from string, options ...Options) (results chan *GetItemsResponse, err error) {
+	pager, err := i.pager(from, options...)
+	if err != nil {
+		return nil, err
 	}
 	results = make(chan *GetItemsResponse, 1)
 	go func() {
 		defer close(results)
-		for {
+		for pager.Next(ctx) {
 			select {
 			case <-ctx.Done():
 				return
-			default:
-				results <- i.GetItems(from)
+			case results <- pager.Page():
+			}
+		}
+		if pager.Err() != nil && ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+			case results <- &GetItemsResponse{Error: pager.Err()}:
 			}
 		}
 	}()
-	return results
+	return results, nil
 }
 
-func (i *Items) GetItems(endpointURI string) *GetItemsResponse {
+// Query returns the query with the Items defaults and the options, the errors of all wrong options are reported at once
+func (i Items) Query(options ...Options) (ItemsQuery, error) {
+	query := i.defaults
+	var errs error
+	for _, option := range options {
+		if err := option(&query); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if errs != nil {
+		return ItemsQuery{}, fmt.Errorf("api (items): options error: %w", errs)
+	}
+	return query, nil
+}
+
+// GetItems gets the page of the query cursor, the cursor of the next page is returned with GetItemsResponse.Cursor
+func (i Items) GetItems(ctx context.Context, endpointURI string, query ItemsQuery) *GetItemsResponse {
 	itemsResp := new(GetItemsResponse)
+	game := query.game
+	if game == "" {
+		game = DefaultGame
+	}
+	currency := query.currency
+	if currency == "" {
+		currency = CurrencyUSD
+	}
 	params := &url.Values{
-		"gameId":    {"9a92"},
-		"currency":  {"USD"},
-		"limit":     {strconv.Itoa(i.limit)},
-		"priceFrom": {strconv.Itoa(i.priceFrom)},
-		"priceTo":   {strconv.Itoa(i.priceTo)},
-		"title":     {i.title},
-		"cursor":    {i.cursor},
-	}
-	resp, err := i.client.Get(endpointURI + params.Encode())
+		"gameId":    {string(game)},
+		"currency":  {string(currency)},
+		"limit":     {strconv.Itoa(query.limit)},
+		"priceFrom": {strconv.Itoa(query.priceFrom)},
+		"priceTo":   {strconv.Itoa(query.priceTo)},
+		"title":     {query.title},
+		"cursor":    {query.cursor},
+	}
+	if query.orderBy != "" {
+		params.Set("orderBy", string(query.orderBy))
+		params.Set("orderDir", string(query.orderDir))
+	}
+	if query.treeFilters != "" {
+		params.Set("treeFilters", query.treeFilters)
+	}
+	if len(query.types) > 0 {
+		params.Set("types", strings.Join(query.types, ","))
+	}
+	if query.exact {
+		params.Set("exact", "true")
+	}
+	resp, err := i.client.GetContext(ctx, endpointURI+params.Encode())
 	if err != nil {
 		itemsResp.Error = fmt.Errorf("api (items): get items request error: %w", err)
 		return itemsResp
 	}
-	if resp.StatusCode != http.StatusOK {
-		itemsResp.Error = fmt.Errorf("api (items) error: %w", ErrorRepresentation{
-			Response: resp,
-		})
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		itemsResp.Error = fmt.Errorf("api (items) error: %w", NewErrorRepresentation(resp))
 		return itemsResp
 	}
 	err = json.Unmarshal(resp.Body.Bytes(), &itemsResp)
@@ -164,6 +283,5 @@ func (i *Items) GetItems(endpointURI string) *GetItemsResponse {
 			"resp code: %s resp body: %s unmarshal error: %s", ErrUnmarshalAPIResponse, resp.Status, resp.Body.String(), err)
 		return itemsResp
 	}
-	i.cursor = itemsResp.Cursor
 	return itemsResp
 }
@@ -14,24 +14,22 @@ func TestItemsPriceRange(t *testing.T) {
 	t.Run("price range setup success", func(t *testing.T) {
 		tests := []struct {
 			name string
-			e    Items
+			e    ItemsQuery
 			args args
 			err  error
 		}{
-			{name: "OK", e: Items{}, args: args{priceFrom: 1, priceTo: 2}, err: nil},
-			{name: "OK:priceFrom==priceTo", e: Items{}, args: args{priceFrom: 1, priceTo: 1}, err: nil},
-			{name: "ERR:priceFrom<priceTo", e: Items{}, args: args{2, 1}, err: ErrIncorrectPriceRange},
-			{name: "ERR:priceFrom<0", e: Items{}, args: args{-1, 1}, err: ErrIncorrectPriceRange},
-			{name: "ERR:priceTo<0", e: Items{}, args: args{1, -1}, err: ErrIncorrectPriceRange},
+			{name: "OK", e: ItemsQuery{}, args: args{priceFrom: 1, priceTo: 2}, err: nil},
+			{name: "OK:priceFrom==priceTo", e: ItemsQuery{}, args: args{priceFrom: 1, priceTo: 1}, err: nil},
+			{name: "ERR:priceFrom<priceTo", e: ItemsQuery{}, args: args{2, 1}, err: ErrIncorrectPriceRange},
+			{name: "ERR:priceFrom<0", e: ItemsQuery{}, args: args{-1, 1}, err: ErrIncorrectPriceRange},
+			{name: "ERR:priceTo<0", e: ItemsQuery{}, args: args{1, -1}, err: ErrIncorrectPriceRange},
 		}
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
 				if tt.err != nil {
-					require.Panics(t, func() {
-						ItemsPriceRange(tt.args.priceFrom, tt.args.priceTo)(&tt.e)
-					})
+					require.ErrorIs(t, ItemsPriceRange(tt.args.priceFrom, tt.args.priceTo)(&tt.e), tt.err)
 				} else {
-					ItemsPriceRange(tt.args.priceFrom, tt.args.priceTo)(&tt.e)
+					require.NoError(t, ItemsPriceRange(tt.args.priceFrom, tt.args.priceTo)(&tt.e))
 					require.Equal(t, tt.args.priceTo, tt.e.priceTo)
 					require.Equal(t, tt.args.priceFrom, tt.e.priceFrom)
 				}
@@ -44,23 +42,21 @@ func TestItemsLimitPerRequest(t *testing.T) {
 	t.Run("item limit per request success", func(t *testing.T) {
 		tests := []struct {
 			name  string
-			e     Items
+			e     ItemsQuery
 			limit int
 			err   error
 		}{
-			{name: "OK:limit==10", e: Items{}, limit: 10, err: nil},
-			{name: "ERR:limit==0", e: Items{}, limit: 0, err: ErrLimitPerRequest},
-			{name: "ERR:limit<=0", e: Items{}, limit: -1, err: ErrLimitPerRequest},
-			{name: "ERR:limit>100", e: Items{}, limit: 101, err: ErrLimitPerRequest},
+			{name: "OK:limit==10", e: ItemsQuery{}, limit: 10, err: nil},
+			{name: "ERR:limit==0", e: ItemsQuery{}, limit: 0, err: ErrLimitPerRequest},
+			{name: "ERR:limit<=0", e: ItemsQuery{}, limit: -1, err: ErrLimitPerRequest},
+			{name: "ERR:limit>100", e: ItemsQuery{}, limit: 101, err: ErrLimitPerRequest},
 		}
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
 				if tt.err != nil {
-					require.Panics(t, func() {
-						ItemsLimitPerRequest(tt.limit)(&tt.e)
-					})
+					require.ErrorIs(t, ItemsLimitPerRequest(tt.limit)(&tt.e), tt.err)
 				} else {
-					ItemsLimitPerRequest(tt.limit)(&tt.e)
+					require.NoError(t, ItemsLimitPerRequest(tt.limit)(&tt.e))
 					require.Equal(t, tt.limit, tt.e.limit)
 				}
 			})
@@ -71,8 +67,51 @@ func TestItemsLimitPerRequest(t *testing.T) {
 func TestItemsTitle(t *testing.T) {
 	t.Run("success: title", func(t *testing.T) {
 		title := "test"
-		i := Items{}
-		ItemsTitle(title)(&i)
+		i := ItemsQuery{}
+		require.NoError(t, ItemsTitle(title)(&i))
 		require.Equal(t, title, i.title)
 	})
 }
+
+func TestItemsGame(t *testing.T) {
+	t.Run("success: game", func(t *testing.T) {
+		i := ItemsQuery{}
+		require.NoError(t, ItemsGame(GameRust)(&i))
+		require.Equal(t, GameRust, i.game)
+	})
+	t.Run("error: empty game", func(t *testing.T) {
+		require.ErrorIs(t, ItemsGame("")(&ItemsQuery{}), ErrIncorrectGame)
+	})
+}
+
+func TestGame_String(t *testing.T) {
+	require.Equal(t, "CS:GO", GameCSGO.String())
+	require.Equal(t, "Dota 2", DefaultGame.String())
+	require.Equal(t, "unknown", Game("unknown").String())
+	require.Len(t, Games(), 4)
+}
+
+func TestItemsCurrency(t *testing.T) {
+	t.Run("success: DMC", func(t *testing.T) {
+		i := ItemsQuery{}
+		require.NoError(t, ItemsCurrency(CurrencyDMC)(&i))
+		require.Equal(t, CurrencyDMC, i.currency)
+	})
+	t.Run("error: unknown currency", func(t *testing.T) {
+		require.ErrorIs(t, ItemsCurrency("EUR")(&ItemsQuery{}), ErrIncorrectCurrency)
+	})
+}
+
+func TestItems_Query(t *testing.T) {
+	e := NewExchange(&recorder{})
+	query, err := e.Items.Query(ItemsTitle("title"), ItemsGame(GameCSGO))
+	require.NoError(t, err)
+	require.Equal(t, "title", query.title)
+	require.Equal(t, GameCSGO, query.game)
+	require.Equal(t, DefaultGame, e.Items.defaults.game, "options must not change the Items defaults")
+
+	next := query.WithCursor("next")
+	require.Empty(t, query.Cursor())
+	require.Equal(t, "next", next.Cursor())
+	require.Equal(t, query.title, next.title)
+}
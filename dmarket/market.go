@@ -0,0 +1,209 @@
+package dmarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	aggregatedPrices = "/price-aggregator/v1/aggregated-prices?"
+	lastSales        = "/trade-aggregator/v1/last-sales?"
+	// aggregatedTitlesLimit is the max count of titles per aggregated prices request
+	aggregatedTitlesLimit = 100
+)
+
+// Market is a service structure for interacting with dmarket price-aggregator and trade-aggregator API endpoints
+type Market struct {
+	client Requester
+}
+
+// NewMarket create new Market endpoint client
+func NewMarket(client Requester) *Market {
+	return &Market{client: client}
+}
+
+/*
+AggregatedPrice is the best market prices of the title
+
+	BestAsk, AskCount - the lowest offer price and the count of offers
+	BestBid, BidCount - the highest target (buy order) price and the count of targets
+*/
+type AggregatedPrice struct {
+	Title    string
+	BestAsk  Cents
+	AskCount int
+	BestBid  Cents
+	BidCount int
+}
+
+// UnmarshalJSON decodes the aggregated title of Dmarket, the prices are sent in dollars like "1.23"
+func (p *AggregatedPrice) UnmarshalJSON(data []byte) error {
+	var title struct {
+		MarketHashName string `json:"MarketHashName"`
+		Offers         struct {
+			BestPrice string `json:"BestPrice"`
+			Count     int    `json:"Count"`
+		} `json:"Offers"`
+		Orders struct {
+			BestPrice string `json:"BestPrice"`
+			Count     int    `json:"Count"`
+		} `json:"Orders"`
+	}
+	if err := json.Unmarshal(data, &title); err != nil {
+		return err
+	}
+	ask, err := parseDollars(title.Offers.BestPrice)
+	if err != nil {
+		return err
+	}
+	bid, err := parseDollars(title.Orders.BestPrice)
+	if err != nil {
+		return err
+	}
+	*p = AggregatedPrice{
+		Title:    title.MarketHashName,
+		BestAsk:  ask,
+		AskCount: title.Offers.Count,
+		BestBid:  bid,
+		BidCount: title.Orders.Count,
+	}
+	return nil
+}
+
+// SaleType is the side of the closed trade
+type SaleType string
+
+const (
+	// SaleOffer is the item bought from the offer
+	SaleOffer SaleType = "Offer"
+	// SaleTarget is the item sold to the target
+	SaleTarget SaleType = "Target"
+)
+
+// Sale is the closed trade of the title
+type Sale struct {
+	Price Cents
+	Date  time.Time
+	Type  SaleType
+}
+
+// UnmarshalJSON decodes the last sale of Dmarket, the price is sent in dollars and the date in unix seconds
+func (s *Sale) UnmarshalJSON(data []byte) error {
+	var sale struct {
+		Price string   `json:"price"`
+		Date  string   `json:"date"`
+		Type  SaleType `json:"txOperationType"`
+	}
+	if err := json.Unmarshal(data, &sale); err != nil {
+		return err
+	}
+	price, err := parseDollars(sale.Price)
+	if err != nil {
+		return err
+	}
+	date, err := strconv.ParseInt(sale.Date, 10, 64)
+	if err != nil {
+		return fmt.Errorf("sale date %q: %w", sale.Date, err)
+	}
+	*s = Sale{Price: price, Date: time.Unix(date, 0).UTC(), Type: sale.Type}
+	return nil
+}
+
+// parseDollars parses the price in dollars, an empty price is zero
+func parseDollars(price string) (Cents, error) {
+	if price == "" {
+		return 0, nil
+	}
+	return ParseCents(price)
+}
+
+type aggregatedPricesResponse struct {
+	AggregatedTitles []AggregatedPrice `json:"AggregatedTitles"`
+}
+
+type lastSalesResponse struct {
+	Sales []Sale `json:"sales"`
+}
+
+/*
+AggregatedPrices gets the best offer and target prices of the titles in the order of the titles,
+the titles are requested by batches of 100. The title without the offers and targets has zero prices.
+
+https://api.dmarket.com/price-aggregator/v1/aggregated-prices?gameId={game}&Titles={title}&Titles={title}
+*/
+func (m Market) AggregatedPrices(ctx context.Context, game Game, titles ...string) ([]AggregatedPrice, error) {
+	if game == "" {
+		return nil, fmt.Errorf("api (market): aggregated prices error: %w", ErrIncorrectGame)
+	}
+	if len(titles) == 0 {
+		return nil, fmt.Errorf("api (market): aggregated prices error: %w", ErrEmptyBatch)
+	}
+	found := make(map[string]AggregatedPrice, len(titles))
+	for start := 0; start < len(titles); start += aggregatedTitlesLimit {
+		end := start + aggregatedTitlesLimit
+		if end > len(titles) {
+			end = len(titles)
+		}
+		query := url.Values{
+			"gameId": {string(game)},
+			"Titles": titles[start:end],
+			"Limit":  {strconv.Itoa(end - start)},
+		}
+		resp, err := m.client.GetContext(ctx, aggregatedPrices+query.Encode())
+		if err != nil {
+			return nil, fmt.Errorf("api (market): aggregated prices request error: %w", err)
+		}
+		var page aggregatedPricesResponse
+		err = decodeResponse(resp, &page)
+		if err != nil {
+			return nil, fmt.Errorf("api (market): aggregated prices error: %w", err)
+		}
+		for _, price := range page.AggregatedTitles {
+			found[price.Title] = price
+		}
+	}
+	prices := make([]AggregatedPrice, 0, len(titles))
+	for _, title := range titles {
+		price, ok := found[title]
+		if !ok {
+			price = AggregatedPrice{Title: title}
+		}
+		prices = append(prices, price)
+	}
+	return prices, nil
+}
+
+/*
+LastSales gets the last sales of the title, the newest first, limit is from 1 to 500
+
+https://api.dmarket.com/trade-aggregator/v1/last-sales?gameId={game}&title={title}&limit={limit}
+*/
+func (m Market) LastSales(ctx context.Context, game Game, title string, limit int) ([]Sale, error) {
+	switch {
+	case game == "":
+		return nil, fmt.Errorf("api (market): last sales error: %w", ErrIncorrectGame)
+	case title == "":
+		return nil, fmt.Errorf("api (market): last sales error: %w", ErrIncorrectTitle)
+	case limit <= 0 || limit > 500:
+		return nil, fmt.Errorf("api (market): last sales error: %w [limit %d] => 0 < limit <= 500", ErrLimitPerRequest, limit)
+	}
+	query := url.Values{
+		"gameId": {string(game)},
+		"title":  {title},
+		"limit":  {strconv.Itoa(limit)},
+	}
+	resp, err := m.client.GetContext(ctx, lastSales+query.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("api (market): last sales request error: %w", err)
+	}
+	var sales lastSalesResponse
+	err = decodeResponse(resp, &sales)
+	if err != nil {
+		return nil, fmt.Errorf("api (market): last sales error: %w", err)
+	}
+	return sales.Sales, nil
+}
@@ -0,0 +1,95 @@
+package dmarket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregatedPrice_UnmarshalJSON(t *testing.T) {
+	var price AggregatedPrice
+	err := json.Unmarshal([]byte(`{"MarketHashName":"AK-47 | Redline (Field-Tested)",`+
+		`"Offers":{"BestPrice":"12.34","Count":10},"Orders":{"BestPrice":"11.5","Count":3}}`), &price)
+	require.NoError(t, err)
+	require.Equal(t, AggregatedPrice{Title: "AK-47 | Redline (Field-Tested)", BestAsk: 1234, AskCount: 10, BestBid: 1150, BidCount: 3}, price)
+
+	err = json.Unmarshal([]byte(`{"MarketHashName":"a","Offers":{"BestPrice":"1.234"}}`), &price)
+	require.ErrorIs(t, err, ErrIncorrectAmount)
+}
+
+func TestSale_UnmarshalJSON(t *testing.T) {
+	var sale Sale
+	err := json.Unmarshal([]byte(`{"price":"1.10","date":"1600000000","txOperationType":"Target"}`), &sale)
+	require.NoError(t, err)
+	require.Equal(t, Sale{Price: 110, Date: time.Unix(1600000000, 0).UTC(), Type: SaleTarget}, sale)
+
+	err = json.Unmarshal([]byte(`{"price":"1.10","date":"yesterday"}`), &sale)
+	require.Error(t, err)
+}
+
+func TestMarket_AggregatedPrices(t *testing.T) {
+	t.Run("batches in the titles order", func(t *testing.T) {
+		titles := make([]string, 150)
+		for i := range titles {
+			titles[i] = "title " + strconv.Itoa(i)
+		}
+		r := &recorder{queue: []Response{
+			respond(http.StatusOK, `{"AggregatedTitles":[{"MarketHashName":"title 1","Offers":{"BestPrice":"2.00","Count":1}}]}`),
+			respond(http.StatusOK, `{"AggregatedTitles":[{"MarketHashName":"title 149","Orders":{"BestPrice":"0.50","Count":4}}]}`),
+		}}
+		prices, err := NewMarket(r).AggregatedPrices(context.Background(), GameCSGO, titles...)
+		require.NoError(t, err)
+		require.Len(t, prices, 150)
+		require.Equal(t, AggregatedPrice{Title: "title 0"}, prices[0])
+		require.Equal(t, AggregatedPrice{Title: "title 1", BestAsk: 200, AskCount: 1}, prices[1])
+		require.Equal(t, AggregatedPrice{Title: "title 149", BestBid: 50, BidCount: 4}, prices[149])
+
+		query, err := url.ParseQuery(strings.TrimPrefix(r.endpoint, aggregatedPrices))
+		require.NoError(t, err)
+		require.Equal(t, titles[100:], query["Titles"])
+		require.Equal(t, string(GameCSGO), query.Get("gameId"))
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := NewMarket(&recorder{}).AggregatedPrices(context.Background(), GameCSGO)
+		require.ErrorIs(t, err, ErrEmptyBatch)
+		_, err = NewMarket(&recorder{}).AggregatedPrices(context.Background(), "", "title")
+		require.ErrorIs(t, err, ErrIncorrectGame)
+		r := &recorder{response: respond(http.StatusTooManyRequests, `{"code":"TooManyRequests","message":"slow down"}`)}
+		_, err = NewMarket(r).AggregatedPrices(context.Background(), GameCSGO, "title")
+		require.ErrorIs(t, err, ErrRateLimited)
+	})
+}
+
+func TestMarket_LastSales(t *testing.T) {
+	r := &recorder{response: respond(http.StatusOK, `{"sales":[{"price":"3.00","date":"1600000000","txOperationType":"Offer"}]}`)}
+	sales, err := NewMarket(r).LastSales(context.Background(), GameDota2, "Arcana", 20)
+	require.NoError(t, err)
+	require.Equal(t, []Sale{{Price: 300, Date: time.Unix(1600000000, 0).UTC(), Type: SaleOffer}}, sales)
+	require.Equal(t, lastSales+"gameId=9a92&limit=20&title=Arcana", r.endpoint)
+
+	tests := []struct {
+		name  string
+		game  Game
+		title string
+		limit int
+		err   error
+	}{
+		{name: "ERR:no game", title: "title", limit: 1, err: ErrIncorrectGame},
+		{name: "ERR:no title", game: GameDota2, limit: 1, err: ErrIncorrectTitle},
+		{name: "ERR:limit==0", game: GameDota2, title: "title", err: ErrLimitPerRequest},
+		{name: "ERR:limit>500", game: GameDota2, title: "title", limit: 501, err: ErrLimitPerRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMarket(&recorder{}).LastSales(context.Background(), tt.game, tt.title, tt.limit)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package dmarket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// MarketplacePrice represent a price in the Dmarket marketplace API format
+//
+// Amount is always stored in cents, the conversion to the API representation
+// (a decimal amount of currency units) happens on JSON marshalling.
+type MarketplacePrice struct {
+	Currency string
+	Amount   int64
+}
+
+type marketplacePrice struct {
+	Currency string      `json:"Currency"`
+	Amount   json.Number `json:"Amount"`
+}
+
+// MarshalJSON encodes the price as {"Currency":"USD","Amount":1.23}
+func (p MarketplacePrice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(marketplacePrice{
+		Currency: p.Currency,
+		Amount:   json.Number(Cents(p.Amount).String()),
+	})
+}
+
+// UnmarshalJSON decodes the price from {"Currency":"USD","Amount":1.23} rounding Amount to cents
+func (p *MarketplacePrice) UnmarshalJSON(data []byte) error {
+	var raw marketplacePrice
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&raw); err != nil {
+		return err
+	}
+	p.Currency = raw.Currency
+	if raw.Amount == "" {
+		p.Amount = 0
+		return nil
+	}
+	amount, err := strconv.ParseFloat(raw.Amount.String(), 64)
+	if err != nil {
+		return fmt.Errorf("marketplace price amount %q: %w", raw.Amount, err)
+	}
+	p.Amount = int64(math.Round(amount * 100))
+	return nil
+}
+
+// MarketplaceError represent a per-entity error reported by the Dmarket marketplace API batch operations
+type MarketplaceError struct {
+	Code    string `json:"Code"`
+	Message string `json:"Message"`
+}
+
+func (e MarketplaceError) Error() string {
+	return fmt.Sprintf("dmarket marketplace error: code %s: %s", e.Code, e.Message)
+}
+
+// failure returns the reported error of an unsuccessful batch entity or a placeholder when Dmarket did not send it
+func failure(e *MarketplaceError) MarketplaceError {
+	if e == nil {
+		return MarketplaceError{Code: "Unknown", Message: "operation was not successful"}
+	}
+	return *e
+}
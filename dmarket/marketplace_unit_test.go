@@ -0,0 +1,59 @@
+package dmarket
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarketplacePrice_JSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		price MarketplacePrice
+		json  string
+	}{
+		{name: "cents", price: MarketplacePrice{Currency: "USD", Amount: 123}, json: `{"Currency":"USD","Amount":1.23}`},
+		{name: "less than dollar", price: MarketplacePrice{Currency: "USD", Amount: 5}, json: `{"Currency":"USD","Amount":0.05}`},
+		{name: "negative", price: MarketplacePrice{Currency: "USD", Amount: -150}, json: `{"Currency":"USD","Amount":-1.50}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.price)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.json, string(b))
+			var price MarketplacePrice
+			require.NoError(t, json.Unmarshal(b, &price))
+			require.Equal(t, tt.price, price)
+		})
+	}
+	t.Run("round to cents", func(t *testing.T) {
+		var price MarketplacePrice
+		require.NoError(t, json.Unmarshal([]byte(`{"Currency":"USD","Amount":0.299999}`), &price))
+		require.Equal(t, int64(30), price.Amount)
+	})
+	t.Run("error: bad amount", func(t *testing.T) {
+		var price MarketplacePrice
+		require.Error(t, json.Unmarshal([]byte(`{"Currency":"USD","Amount":"x"}`), &price))
+	})
+}
+
+func TestCreateOffersResponse_Err(t *testing.T) {
+	t.Run("all successful", func(t *testing.T) {
+		resp := CreateOffersResponse{Result: []CreateOfferResult{{Successful: true}, {Successful: true}}}
+		require.NoError(t, resp.Err())
+	})
+	t.Run("partial failure", func(t *testing.T) {
+		resp := CreateOffersResponse{Result: []CreateOfferResult{
+			{Successful: true},
+			{CreateOffer: CreateOffer{AssetID: "asset"}, Error: &MarketplaceError{Code: "AssetNotFound"}},
+			{CreateOffer: CreateOffer{AssetID: "unknown"}},
+		}}
+		err := resp.Err()
+		var marketplaceErr MarketplaceError
+		require.ErrorAs(t, err, &marketplaceErr)
+		require.Equal(t, "AssetNotFound", marketplaceErr.Code)
+		require.Contains(t, err.Error(), "asset asset")
+		require.Contains(t, err.Error(), "asset unknown")
+	})
+}
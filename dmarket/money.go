@@ -0,0 +1,180 @@
+package dmarket
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Cents is a money amount in the smallest currency units, Dmarket sends it as a string or a number of cents
+type Cents int64
+
+// MarshalJSON encodes cents as a string like Dmarket does
+func (c Cents) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatInt(int64(c), 10))
+}
+
+// UnmarshalJSON decodes cents from a string or a number, an empty string is zero
+func (c *Cents) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		*c = 0
+		return nil
+	}
+	v, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("cents %q: %w", data, err)
+	}
+	*c = Cents(v)
+	return nil
+}
+
+// String formats cents as a decimal amount of currency units, like 12.34
+func (c Cents) String() string {
+	sign, v := "", int64(c)
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/100, v%100)
+}
+
+/*
+ParseCents parses a decimal amount of currency units, like "12.34", "-1.5" or "7", into cents
+
+More than two fractional digits are not allowed, so the amount is never rounded.
+*/
+func ParseCents(amount string) (Cents, error) {
+	s := strings.TrimSpace(amount)
+	sign := int64(1)
+	if strings.HasPrefix(s, "-") {
+		sign, s = -1, s[1:]
+	}
+	units, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		units, fraction = s[:i], s[i+1:]
+	}
+	if units == "" || len(fraction) > 2 || strings.ContainsAny(units+fraction, "+-") {
+		return 0, fmt.Errorf("%w: %q", ErrIncorrectAmount, amount)
+	}
+	u, err := strconv.ParseInt(units, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrIncorrectAmount, amount)
+	}
+	var f int64
+	if fraction != "" {
+		f, err = strconv.ParseInt((fraction + "0")[:2], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrIncorrectAmount, amount)
+		}
+	}
+	return Cents(sign * (u*100 + f)), nil
+}
+
+// Currency is the Dmarket price currency
+type Currency string
+
+const (
+	CurrencyUSD Currency = "USD"
+	// CurrencyDMC is the Dmarket coin
+	CurrencyDMC Currency = "DMC"
+)
+
+var (
+	// ErrIncorrectCurrency indicates a currency that Dmarket does not price items in
+	ErrIncorrectCurrency = errors.New("currency must be USD or DMC")
+	// ErrCurrencyMismatch indicates arithmetic of Money in different currencies
+	ErrCurrencyMismatch = errors.New("money currencies do not match")
+	// ErrIncorrectAmount indicates an amount that can not be parsed into cents
+	ErrIncorrectAmount = errors.New("incorrect money amount")
+)
+
+// Valid reports whether Dmarket prices items in the currency
+func (c Currency) Valid() bool {
+	return c == CurrencyUSD || c == CurrencyDMC
+}
+
+/*
+Money is an amount of cents in the currency, encoded as {"amount":"1234","currency":"USD"}
+
+The arithmetic of Money in different currencies returns ErrCurrencyMismatch.
+*/
+type Money struct {
+	Amount   Cents    `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+// NewMoney creates Money of the amount in cents
+func NewMoney(amount Cents, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney parses a decimal amount of currency units, see ParseCents
+func ParseMoney(amount string, currency Currency) (Money, error) {
+	cents, err := ParseCents(amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: cents, Currency: currency}, nil
+}
+
+// match reports Money in the different currency with ErrCurrencyMismatch
+func (m Money) match(o Money) error {
+	if m.Currency != o.Currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return nil
+}
+
+// Add returns m + o
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.match(o); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount + o.Amount, Currency: m.Currency}, nil
+}
+
+// Sub returns m - o
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.match(o); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount - o.Amount, Currency: m.Currency}, nil
+}
+
+// Mul returns m * n
+func (m Money) Mul(n int64) Money {
+	return Money{Amount: m.Amount * Cents(n), Currency: m.Currency}
+}
+
+// Fraction returns the part of m, like 0.1 for 10%, rounded half away from zero to cents
+func (m Money) Fraction(fraction float64) Money {
+	return Money{Amount: Cents(math.Round(float64(m.Amount) * fraction)), Currency: m.Currency}
+}
+
+// Cmp compares m and o and returns -1 if m < o, 0 if m == o and +1 if m > o
+func (m Money) Cmp(o Money) (int, error) {
+	if err := m.match(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Amount < o.Amount:
+		return -1, nil
+	case m.Amount > o.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// String formats the money like "12.34 USD"
+func (m Money) String() string {
+	return m.Amount.String() + " " + string(m.Currency)
+}
@@ -0,0 +1,137 @@
+package dmarket
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCents_JSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want Cents
+		err  bool
+	}{
+		{name: "OK:string", json: `"1234"`, want: 1234},
+		{name: "OK:number", json: `1234`, want: 1234},
+		{name: "OK:empty string", json: `""`, want: 0},
+		{name: "OK:null", json: `null`, want: 0},
+		{name: "OK:negative", json: `"-5"`, want: -5},
+		{name: "ERR:decimal", json: `"12.34"`, err: true},
+		{name: "ERR:text", json: `"USD"`, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Cents
+			err := json.Unmarshal([]byte(tt.json), &c)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, c)
+		})
+	}
+	t.Run("marshal", func(t *testing.T) {
+		b, err := json.Marshal(Cents(1234))
+		require.NoError(t, err)
+		require.Equal(t, `"1234"`, string(b))
+	})
+}
+
+func TestCents_String(t *testing.T) {
+	require.Equal(t, "12.34", Cents(1234).String())
+	require.Equal(t, "0.05", Cents(5).String())
+	require.Equal(t, "-1.50", Cents(-150).String())
+	require.Equal(t, "0.00", Cents(0).String())
+}
+
+func TestParseCents(t *testing.T) {
+	tests := []struct {
+		amount string
+		want   Cents
+		err    bool
+	}{
+		{amount: "12.34", want: 1234},
+		{amount: "12.3", want: 1230},
+		{amount: "7", want: 700},
+		{amount: "-1.5", want: -150},
+		{amount: " 0.05 ", want: 5},
+		{amount: "1.234", err: true},
+		{amount: "", err: true},
+		{amount: ".5", err: true},
+		{amount: "1.-5", err: true},
+		{amount: "+1", err: true},
+		{amount: "USD", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			got, err := ParseCents(tt.amount)
+			if tt.err {
+				require.ErrorIs(t, err, ErrIncorrectAmount)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMoney(t *testing.T) {
+	usd := NewMoney(1000, CurrencyUSD)
+	t.Run("arithmetic", func(t *testing.T) {
+		sum, err := usd.Add(NewMoney(250, CurrencyUSD))
+		require.NoError(t, err)
+		require.Equal(t, NewMoney(1250, CurrencyUSD), sum)
+		diff, err := usd.Sub(NewMoney(250, CurrencyUSD))
+		require.NoError(t, err)
+		require.Equal(t, NewMoney(750, CurrencyUSD), diff)
+		require.Equal(t, NewMoney(3000, CurrencyUSD), usd.Mul(3))
+		require.Equal(t, NewMoney(70, CurrencyUSD), NewMoney(999, CurrencyUSD).Fraction(0.07))
+		for want, o := range map[int]Money{-1: NewMoney(1001, CurrencyUSD), 0: NewMoney(1000, CurrencyUSD), 1: NewMoney(999, CurrencyUSD)} {
+			cmp, err := usd.Cmp(o)
+			require.NoError(t, err)
+			require.Equal(t, want, cmp)
+		}
+		require.True(t, NewMoney(0, CurrencyDMC).IsZero())
+	})
+	t.Run("error: currency mismatch", func(t *testing.T) {
+		_, err := usd.Add(NewMoney(1, CurrencyDMC))
+		require.ErrorIs(t, err, ErrCurrencyMismatch)
+		_, err = usd.Sub(NewMoney(1, CurrencyDMC))
+		require.ErrorIs(t, err, ErrCurrencyMismatch)
+		_, err = usd.Cmp(NewMoney(1, CurrencyDMC))
+		require.ErrorIs(t, err, ErrCurrencyMismatch)
+	})
+	t.Run("format and parse", func(t *testing.T) {
+		require.Equal(t, "10.00 USD", usd.String())
+		m, err := ParseMoney("10", CurrencyUSD)
+		require.NoError(t, err)
+		require.Equal(t, usd, m)
+		_, err = ParseMoney("ten", CurrencyUSD)
+		require.ErrorIs(t, err, ErrIncorrectAmount)
+	})
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(usd)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"amount":"1000","currency":"USD"}`, string(b))
+		var m Money
+		require.NoError(t, json.Unmarshal(b, &m))
+		require.Equal(t, usd, m)
+	})
+}
+
+func TestPrice_JSON(t *testing.T) {
+	var object Object
+	err := json.Unmarshal([]byte(`{"price":{"DMC":"1234","USD":"1100"},"suggestedPrice":{"DMC":"","USD":"999"},`+
+		`"recommendedPrice":{"d3":{"USD":"10"},"d7":{"USD":20},"d7Plus":{"USD":"30"}}}`), &object)
+	require.NoError(t, err)
+	require.Equal(t, NewMoney(1100, CurrencyUSD), object.Price.USD())
+	require.Equal(t, NewMoney(1234, CurrencyDMC), object.Price.In(CurrencyDMC))
+	require.Equal(t, Cents(999), object.SuggestedPrice.Usd)
+	require.Equal(t, Cents(0), object.SuggestedPrice.Dmc)
+	require.Equal(t, RecommendedPrice{D3: Price{Usd: 10}, D7: Price{Usd: 20}, D7Plus: Price{Usd: 30}}, object.RecommendedPrice)
+	require.Equal(t, Money{Currency: "EUR"}, object.Price.In("EUR"))
+}
@@ -0,0 +1,178 @@
+package dmarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	createOffers = "/marketplace-api/v1/user-offers/create"
+	editOffers   = "/marketplace-api/v1/user-offers/edit"
+	deleteOffers = "/marketplace-api/v1/user-offers/delete"
+)
+
+// ErrEmptyBatch indicates an attempt to send a batch operation without any entity
+var ErrEmptyBatch = errors.New("batch request must contain at least one entity")
+
+// Offers is a service structure for interacting with dmarket user offers API endpoints
+type Offers struct {
+	client Requester
+}
+
+// CreateOffer describes a new sell offer for the user inventory asset
+type CreateOffer struct {
+	AssetID string           `json:"AssetID"`
+	Price   MarketplacePrice `json:"Price"`
+}
+
+// EditOffer describes a new price for the existing sell offer
+type EditOffer struct {
+	OfferID string           `json:"OfferID"`
+	AssetID string           `json:"AssetID"`
+	Price   MarketplacePrice `json:"Price"`
+}
+
+// DeleteOffer describes the sell offer to be removed from the market
+type DeleteOffer struct {
+	OfferID string `json:"OfferID"`
+	AssetID string `json:"AssetID"`
+}
+
+type CreateOfferResult struct {
+	CreateOffer CreateOffer       `json:"CreateOffer"`
+	OfferID     string            `json:"OfferID"`
+	Successful  bool              `json:"Successful"`
+	Error       *MarketplaceError `json:"Error"`
+}
+
+type EditOfferResult struct {
+	EditOffer  EditOffer         `json:"EditOffer"`
+	NewOfferID string            `json:"NewOfferID"`
+	Successful bool              `json:"Successful"`
+	Error      *MarketplaceError `json:"Error"`
+}
+
+type DeleteOfferResult struct {
+	DeleteOffer DeleteOffer       `json:"DeleteOffer"`
+	Successful  bool              `json:"Successful"`
+	Error       *MarketplaceError `json:"Error"`
+}
+
+type CreateOffersResponse struct {
+	Result []CreateOfferResult `json:"Result"`
+}
+
+type EditOffersResponse struct {
+	Result []EditOfferResult `json:"Result"`
+}
+
+type DeleteOffersResponse struct {
+	Result []DeleteOfferResult `json:"Result"`
+}
+
+/*
+Err reports the offers that Dmarket did not create.
+
+Dmarket processes the batch partially, so a nil error from Offers.Create does not mean that every offer was placed.
+Each failed offer is represented by a MarketplaceError wrapped with its asset ID.
+*/
+func (r CreateOffersResponse) Err() error {
+	var errs error
+	for _, result := range r.Result {
+		if !result.Successful {
+			errs = multierror.Append(errs, fmt.Errorf("asset %s: %w", result.CreateOffer.AssetID, failure(result.Error)))
+		}
+	}
+	return errs
+}
+
+// Err reports the offers that Dmarket did not edit, see CreateOffersResponse.Err
+func (r EditOffersResponse) Err() error {
+	var errs error
+	for _, result := range r.Result {
+		if !result.Successful {
+			errs = multierror.Append(errs, fmt.Errorf("offer %s: %w", result.EditOffer.OfferID, failure(result.Error)))
+		}
+	}
+	return errs
+}
+
+// Err reports the offers that Dmarket did not delete, see CreateOffersResponse.Err
+func (r DeleteOffersResponse) Err() error {
+	var errs error
+	for _, result := range r.Result {
+		if !result.Successful {
+			errs = multierror.Append(errs, fmt.Errorf("offer %s: %w", result.DeleteOffer.OfferID, failure(result.Error)))
+		}
+	}
+	return errs
+}
+
+/*
+Create places sell offers for the user inventory assets
+
+https://api.dmarket.com/marketplace-api/v1/user-offers/create
+
+The returned error reports only request, HTTP and decoding failures,
+per-offer failures are available with CreateOffersResponse.Err.
+*/
+func (o Offers) Create(ctx context.Context, offers ...CreateOffer) (*CreateOffersResponse, error) {
+	if len(offers) == 0 {
+		return nil, fmt.Errorf("api (offers): create offers error: %w", ErrEmptyBatch)
+	}
+	resp := new(CreateOffersResponse)
+	err := sendJSON(ctx, o.client.PostContext, createOffers, struct {
+		Offers []CreateOffer `json:"Offers"`
+	}{offers}, resp)
+	if err != nil {
+		return nil, fmt.Errorf("api (offers): create offers error: %w", err)
+	}
+	return resp, nil
+}
+
+/*
+Edit changes prices of the existing sell offers
+
+https://api.dmarket.com/marketplace-api/v1/user-offers/edit
+
+The returned error reports only request, HTTP and decoding failures,
+per-offer failures are available with EditOffersResponse.Err.
+*/
+func (o Offers) Edit(ctx context.Context, offers ...EditOffer) (*EditOffersResponse, error) {
+	if len(offers) == 0 {
+		return nil, fmt.Errorf("api (offers): edit offers error: %w", ErrEmptyBatch)
+	}
+	resp := new(EditOffersResponse)
+	err := sendJSON(ctx, o.client.PostContext, editOffers, struct {
+		Offers []EditOffer `json:"Offers"`
+	}{offers}, resp)
+	if err != nil {
+		return nil, fmt.Errorf("api (offers): edit offers error: %w", err)
+	}
+	return resp, nil
+}
+
+/*
+Delete removes sell offers from the market
+
+https://api.dmarket.com/marketplace-api/v1/user-offers/delete
+
+The returned error reports only request, HTTP and decoding failures,
+per-offer failures are available with DeleteOffersResponse.Err.
+*/
+func (o Offers) Delete(ctx context.Context, offers ...DeleteOffer) (*DeleteOffersResponse, error) {
+	if len(offers) == 0 {
+		return nil, fmt.Errorf("api (offers): delete offers error: %w", ErrEmptyBatch)
+	}
+	resp := new(DeleteOffersResponse)
+	err := sendJSON(ctx, o.client.PostContext, deleteOffers, struct {
+		Offers []DeleteOffer `json:"Offers"`
+	}{offers}, resp)
+	if err != nil {
+		return nil, fmt.Errorf("api (offers): delete offers error: %w", err)
+	}
+	return resp, nil
+}
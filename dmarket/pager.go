@@ -0,0 +1,92 @@
+package dmarket
+
+import "context"
+
+/*
+ItemsPager iterates over the pages of the Items query until the cursor is exhausted
+
+	pager, err := exchange.Items.PagerFromDmarket(ItemsTitle("title"))
+	for pager.Next(ctx) {
+		objects = append(objects, pager.Page().Objects...)
+	}
+	err = pager.Err()
+
+The pager stops on the first error, the page without objects or the page without the next cursor.
+The pager is not safe for concurrent use, run a pager per goroutine.
+*/
+type ItemsPager struct {
+	items Items
+	from  string
+	query ItemsQuery
+	page  *GetItemsResponse
+	err   error
+	done  bool
+}
+
+// PagerFromDmarket returns the pager of the objects available on the Dmarket exchange, see GetAllItemsFromDmarket options
+func (i Items) PagerFromDmarket(options ...Options) (*ItemsPager, error) {
+	return i.pager(marketItems, options...)
+}
+
+// PagerFromUserInventory returns the pager of the objects of the user inventory, see GetAllItemsFromUserInventory options
+func (i Items) PagerFromUserInventory(options ...Options) (*ItemsPager, error) {
+	return i.pager(userItems, options...)
+}
+
+func (i Items) pager(from string, options ...Options) (*ItemsPager, error) {
+	query, err := i.Query(options...)
+	if err != nil {
+		return nil, err
+	}
+	return &ItemsPager{items: i, from: from, query: query}, nil
+}
+
+// Next requests the next page, it returns false when the pages are over or on the error
+func (p *ItemsPager) Next(ctx context.Context) bool {
+	if p.done {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		p.err, p.done = err, true
+		return false
+	}
+	page := p.items.GetItems(ctx, p.from, p.query)
+	if page.Error != nil {
+		p.err, p.done, p.page = page.Error, true, nil
+		return false
+	}
+	if len(page.Objects) == 0 {
+		p.done, p.page = true, nil
+		return false
+	}
+	p.page = page
+	p.done = page.Cursor == "" || page.Cursor == p.query.Cursor()
+	p.query = p.query.WithCursor(page.Cursor)
+	return true
+}
+
+// Page returns the page received by the last successful Next
+func (p *ItemsPager) Page() *GetItemsResponse {
+	return p.page
+}
+
+// Err returns the error that stopped the pager, nil when the pages are over
+func (p *ItemsPager) Err() error {
+	return p.err
+}
+
+/*
+CollectAll collects the objects of the rest pages, but not more than max objects.
+With max <= 0 all objects are collected. The objects collected before the error are returned with it.
+*/
+func (p *ItemsPager) CollectAll(ctx context.Context, max int) ([]Object, error) {
+	var objects []Object
+	for p.Next(ctx) {
+		objects = append(objects, p.Page().Objects...)
+		if max > 0 && len(objects) >= max {
+			p.done = true
+			return objects[:max], nil
+		}
+	}
+	return objects, p.Err()
+}
@@ -0,0 +1,51 @@
+package dmarket
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemsPager_Next(t *testing.T) {
+	t.Run("cursor", func(t *testing.T) {
+		r := &recorder{queue: []Response{
+			respond(http.StatusOK, `{"objects":[{"itemId":"1"}],"cursor":"c1"}`),
+			respond(http.StatusOK, `{"objects":[{"itemId":"2"}],"cursor":"c2"}`),
+			respond(http.StatusOK, `{"objects":[{"itemId":"3"}],"cursor":""}`),
+		}}
+		pager, err := NewExchange(r).Items.PagerFromUserInventory()
+		require.NoError(t, err)
+		var cursors, ids []string
+		for pager.Next(context.Background()) {
+			query, err := url.ParseQuery(strings.TrimPrefix(r.endpoint, userItems))
+			require.NoError(t, err)
+			cursors = append(cursors, query.Get("cursor"))
+			ids = append(ids, pager.Page().Objects[0].ItemID)
+		}
+		require.NoError(t, pager.Err())
+		require.Equal(t, []string{"", "c1", "c2"}, cursors)
+		require.Equal(t, []string{"1", "2", "3"}, ids)
+	})
+	t.Run("repeated cursor", func(t *testing.T) {
+		r := &recorder{response: respond(http.StatusOK, `{"objects":[{"itemId":"1"}],"cursor":"c1"}`)}
+		pager, err := NewExchange(r).Items.PagerFromDmarket()
+		require.NoError(t, err)
+		objects, err := pager.CollectAll(context.Background(), 0)
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+	})
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		r := &recorder{}
+		pager, err := NewExchange(r).Items.PagerFromDmarket()
+		require.NoError(t, err)
+		require.False(t, pager.Next(ctx))
+		require.ErrorIs(t, pager.Err(), context.Canceled)
+		require.Empty(t, r.endpoint)
+	})
+}
@@ -0,0 +1,179 @@
+package dmarket
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// EndpointGroup is a group of Dmarket API endpoints which share the same rate limit
+type EndpointGroup string
+
+const (
+	// GroupMarket is market browsing: market and inventory items, aggregated prices and last sales
+	GroupMarket EndpointGroup = "market"
+	// GroupTrading is offers, targets and purchases
+	GroupTrading EndpointGroup = "trading"
+	// GroupAccount is the user balance and profile
+	GroupAccount EndpointGroup = "account"
+	// GroupFees is the customized fees
+	GroupFees EndpointGroup = "fees"
+	// GroupOther is every endpoint that does not belong to another group
+	GroupOther EndpointGroup = "other"
+)
+
+var endpointGroups = []struct {
+	prefix string
+	group  EndpointGroup
+}{
+	{prefix: "/exchange/v1/market/", group: GroupMarket},
+	{prefix: "/exchange/v1/user/items", group: GroupMarket},
+	{prefix: "/price-aggregator/", group: GroupMarket},
+	{prefix: "/trade-aggregator/", group: GroupMarket},
+	{prefix: "/exchange/v1/customized-fees", group: GroupFees},
+	{prefix: "/exchange/v1/offers", group: GroupTrading},
+	{prefix: "/marketplace-api/", group: GroupTrading},
+	{prefix: "/account/", group: GroupAccount},
+}
+
+/*
+EndpointGroupOf returns the rate limit group of the endpoint
+
+The endpoint may be a path with a query (as passed to Requester) or an absolute URL.
+*/
+func EndpointGroupOf(endpoint string) EndpointGroup {
+	path := endpoint
+	if u, err := url.Parse(endpoint); err == nil {
+		path = u.Path
+	}
+	for _, g := range endpointGroups {
+		if strings.HasPrefix(path, g.prefix) {
+			return g.group
+		}
+	}
+	return GroupOther
+}
+
+/*
+DefaultGroupRateLimits mirrors the limits published by Dmarket
+
+	market  - 10 requests per second
+	trading - 20 requests per second
+	account - 20 requests per second
+	fees    - 110 requests per minute
+	other   - 20 requests per second
+
+NewClient also keeps the global limit of 5 requests per second on top of the groups, see WithRateLimit.
+*/
+func DefaultGroupRateLimits() map[EndpointGroup]rate.Limit {
+	return map[EndpointGroup]rate.Limit{
+		GroupMarket:  10,
+		GroupTrading: 20,
+		GroupAccount: 20,
+		GroupFees:    rate.Every(time.Minute / 110),
+		GroupOther:   20,
+	}
+}
+
+type groupLimiter struct {
+	limiter *rate.Limiter
+	// limit is the configured limit, the limiter works below it after Throttle
+	limit rate.Limit
+}
+
+/*
+RateLimits is a registry of rate limiters keyed by EndpointGroup, the zero value has no limits
+
+A group is slowed down with Throttle when Dmarket answers 429 Too Many Requests
+and gradually restored to the configured limit with Recover after successful responses.
+*/
+type RateLimits struct {
+	mu     sync.Mutex
+	groups map[EndpointGroup]*groupLimiter
+}
+
+// NewRateLimits creates the registry with DefaultGroupRateLimits and burst 1 for every group
+func NewRateLimits() *RateLimits {
+	r := new(RateLimits)
+	for group, limit := range DefaultGroupRateLimits() {
+		r.Set(group, limit, 1)
+	}
+	return r
+}
+
+// Set configures the group limit, the group without a limit is not limited
+func (r *RateLimits) Set(group EndpointGroup, limit rate.Limit, burst int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.groups == nil {
+		r.groups = make(map[EndpointGroup]*groupLimiter)
+	}
+	r.groups[group] = &groupLimiter{limiter: rate.NewLimiter(limit, burst), limit: limit}
+}
+
+// Limit returns the current limit of the group
+func (r *RateLimits) Limit(group EndpointGroup) rate.Limit {
+	g := r.group(group)
+	if g == nil {
+		return rate.Inf
+	}
+	return g.limiter.Limit()
+}
+
+// Wait blocks until the group limiter permits a request or ctx is done
+func (r *RateLimits) Wait(ctx context.Context, group EndpointGroup) error {
+	g := r.group(group)
+	if g == nil {
+		return nil
+	}
+	return g.limiter.Wait(ctx)
+}
+
+// Allow reports whether a request of the group may happen now
+func (r *RateLimits) Allow(group EndpointGroup) bool {
+	g := r.group(group)
+	if g == nil {
+		return true
+	}
+	return g.limiter.Allow()
+}
+
+// Throttle halves the current limit of the group, but not below a tenth of the configured limit
+func (r *RateLimits) Throttle(group EndpointGroup) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	g, ok := r.groups[group]
+	if !ok || g.limit == rate.Inf {
+		return
+	}
+	limit := g.limiter.Limit() / 2
+	if floor := g.limit / 10; limit < floor {
+		limit = floor
+	}
+	g.limiter.SetLimit(limit)
+}
+
+// Recover raises the current limit of the throttled group by a tenth up to the configured limit
+func (r *RateLimits) Recover(group EndpointGroup) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	g, ok := r.groups[group]
+	if !ok || g.limiter.Limit() >= g.limit {
+		return
+	}
+	limit := g.limiter.Limit() + g.limit/10
+	if limit > g.limit {
+		limit = g.limit
+	}
+	g.limiter.SetLimit(limit)
+}
+
+func (r *RateLimits) group(group EndpointGroup) *groupLimiter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.groups[group]
+}
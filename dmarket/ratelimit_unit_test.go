@@ -0,0 +1,70 @@
+package dmarket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestEndpointGroupOf(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		group    EndpointGroup
+	}{
+		{endpoint: marketItems + "gameId=a8db", group: GroupMarket},
+		{endpoint: userItems + "gameId=a8db", group: GroupMarket},
+		{endpoint: "https://api.dmarket.com/exchange/v1/market/items?gameId=a8db", group: GroupMarket},
+		{endpoint: "/price-aggregator/v1/aggregated-prices", group: GroupMarket},
+		{endpoint: createOffers, group: GroupTrading},
+		{endpoint: userTargets + "GameID=a8db", group: GroupTrading},
+		{endpoint: offersBuy, group: GroupTrading},
+		{endpoint: accountBalance, group: GroupAccount},
+		{endpoint: customizedFees + "gameId=a8db", group: GroupFees},
+		{endpoint: "/", group: GroupOther},
+		{endpoint: "/game/v1/games", group: GroupOther},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			require.Equal(t, tt.group, EndpointGroupOf(tt.endpoint))
+		})
+	}
+}
+
+func TestRateLimits(t *testing.T) {
+	t.Run("zero value is not limited", func(t *testing.T) {
+		var r RateLimits
+		require.Equal(t, rate.Inf, r.Limit(GroupMarket))
+		for i := 0; i < 100; i++ {
+			require.True(t, r.Allow(GroupMarket))
+		}
+		require.NoError(t, r.Wait(context.Background(), GroupMarket))
+		r.Throttle(GroupMarket)
+		r.Recover(GroupMarket)
+	})
+	t.Run("groups are independent", func(t *testing.T) {
+		r := NewRateLimits()
+		r.Set(GroupMarket, rate.Limit(1), 1)
+		require.True(t, r.Allow(GroupMarket))
+		require.False(t, r.Allow(GroupMarket))
+		require.True(t, r.Allow(GroupTrading))
+	})
+	t.Run("throttle and recover", func(t *testing.T) {
+		r := NewRateLimits()
+		r.Set(GroupMarket, rate.Limit(10), 1)
+		r.Throttle(GroupMarket)
+		require.Equal(t, rate.Limit(5), r.Limit(GroupMarket))
+		for i := 0; i < 10; i++ {
+			r.Throttle(GroupMarket)
+		}
+		require.Equal(t, rate.Limit(1), r.Limit(GroupMarket))
+		r.Recover(GroupMarket)
+		require.Equal(t, rate.Limit(2), r.Limit(GroupMarket))
+		for i := 0; i < 20; i++ {
+			r.Recover(GroupMarket)
+		}
+		require.Equal(t, rate.Limit(10), r.Limit(GroupMarket))
+		require.Equal(t, rate.Limit(20), r.Limit(GroupTrading))
+	})
+}
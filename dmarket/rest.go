@@ -2,24 +2,50 @@ package dmarket
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 )
 
+/*
+Requester sends signed requests to the Dmarket API endpoints
+
+The Context methods bind the request to ctx: cancelling ctx aborts the request (or the wait for the rate limiter),
+the methods without ctx use context.Background().
+*/
 type Requester interface {
 	Get(endpoint string) (Response, error)
 	Post(endpoint string, body io.Reader) (Response, error)
 	Delete(endpoint string, body io.Reader) (Response, error)
 	Patch(endpoint string, body io.Reader) (Response, error)
+	GetContext(ctx context.Context, endpoint string) (Response, error)
+	PostContext(ctx context.Context, endpoint string, body io.Reader) (Response, error)
+	DeleteContext(ctx context.Context, endpoint string, body io.Reader) (Response, error)
+	PatchContext(ctx context.Context, endpoint string, body io.Reader) (Response, error)
 }
 
+/*
+ErrorRepresentation is the error of the non-2xx Dmarket response
+
+Runtime is the decoded Dmarket error body, check the common failures with errors.Is:
+	ErrInsufficientFunds
+	ErrItemNotFound
+	ErrInvalidSignature
+	ErrRateLimited
+*/
 type ErrorRepresentation struct {
 	Response Response
+	Runtime  *RuntimeError
 }
 
 func (e ErrorRepresentation) Error() string {
-	return fmt.Sprintf("dmarket API representation error: code %d: %s", e.Response.StatusCode, http.StatusText(e.Response.StatusCode))
+	msg := fmt.Sprintf("dmarket API representation error: code %d: %s", e.Response.StatusCode, http.StatusText(e.Response.StatusCode))
+	if e.Runtime != nil {
+		msg += fmt.Sprintf(": %s: %s", e.Runtime.Err, e.Runtime.Message)
+	}
+	return msg
 }
 
 func (e ErrorRepresentation) String() (int, string) {
@@ -51,9 +77,41 @@ type Response struct {
 	ContentLength int64
 	Body          *bytes.Buffer
 	Request       *http.Request
+	// Attempts is the number of times the request was sent, see RetryPolicy
+	Attempts int
 }
 
 func (r *Response) ReadFrom(reader io.Reader) (n int64, err error) {
 	r.Body = new(bytes.Buffer)
 	return r.Body.ReadFrom(reader)
 }
+
+// sendJSON marshals in as the request body, sends it with the given Requester method and decodes the response into out
+func sendJSON(ctx context.Context, send func(ctx context.Context, endpoint string, body io.Reader) (Response, error),
+	endpoint string, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("marshal request body error: %w", err)
+	}
+	resp, err := send(ctx, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("request error: %w", err)
+	}
+	return decodeResponse(resp, out)
+}
+
+// decodeResponse unmarshal the body of a successful Dmarket response into out, any other response is an ErrorRepresentation
+func decodeResponse(resp Response, out interface{}) error {
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return NewErrorRepresentation(resp)
+	}
+	if resp.Body == nil || resp.Body.Len() == 0 {
+		return nil
+	}
+	err := json.Unmarshal(resp.Body.Bytes(), out)
+	if err != nil {
+		return fmt.Errorf("%w resp code: %s resp body: %s unmarshal error: %s",
+			ErrUnmarshalAPIResponse, resp.Status, resp.Body.String(), err)
+	}
+	return nil
+}
@@ -2,7 +2,9 @@ package dmarket
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"io"
 	"net/http"
 	"testing"
 	"testing/iotest"
@@ -50,3 +52,60 @@ func TestResponse_ReadFrom(t *testing.T) {
 		require.Zero(t, i)
 	})
 }
+
+// recorder is a Requester that records the last request and replies with the prepared response,
+// the queued responses are replied first
+type recorder struct {
+	method, endpoint string
+	body             []byte
+	response         Response
+	queue            []Response
+}
+
+func (r *recorder) Get(endpoint string) (Response, error) {
+	return r.GetContext(context.Background(), endpoint)
+}
+
+func (r *recorder) Post(endpoint string, body io.Reader) (Response, error) {
+	return r.PostContext(context.Background(), endpoint, body)
+}
+
+func (r *recorder) Delete(endpoint string, body io.Reader) (Response, error) {
+	return r.DeleteContext(context.Background(), endpoint, body)
+}
+
+func (r *recorder) Patch(endpoint string, body io.Reader) (Response, error) {
+	return r.PatchContext(context.Background(), endpoint, body)
+}
+
+func (r *recorder) GetContext(_ context.Context, endpoint string) (Response, error) {
+	return r.record(http.MethodGet, endpoint, http.NoBody)
+}
+
+func (r *recorder) PostContext(_ context.Context, endpoint string, body io.Reader) (Response, error) {
+	return r.record(http.MethodPost, endpoint, body)
+}
+
+func (r *recorder) DeleteContext(_ context.Context, endpoint string, body io.Reader) (Response, error) {
+	return r.record(http.MethodDelete, endpoint, body)
+}
+
+func (r *recorder) PatchContext(_ context.Context, endpoint string, body io.Reader) (Response, error) {
+	return r.record(http.MethodPatch, endpoint, body)
+}
+
+func (r *recorder) record(method, endpoint string, body io.Reader) (Response, error) {
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return Response{}, err
+	}
+	r.method, r.endpoint, r.body = method, endpoint, b
+	if len(r.queue) > 0 {
+		r.response, r.queue = r.queue[0], r.queue[1:]
+	}
+	return r.response, nil
+}
+
+func respond(code int, body string) Response {
+	return Response{StatusCode: code, Status: http.StatusText(code), Body: bytes.NewBufferString(body)}
+}
@@ -0,0 +1,109 @@
+package dmarket
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+/*
+RetryPolicy controls how Do retries the requests that Dmarket answered with 429 Too Many Requests or 5xx
+
+	MaxAttempts - total number of attempts including the first one, 1 disables retries
+	BaseDelay   - delay before the second attempt, doubled for every next attempt
+	MaxDelay    - upper bound of the backoff delay
+
+The backoff delay has a random jitter, the Retry-After header of the response takes precedence over it,
+but it is capped with MaxDelay too, so the server can not stall the client for longer than MaxDelay.
+Requests that are not idempotent (POST, PATCH without an Idempotency-Key header) are retried only after
+429 Too Many Requests, because Dmarket rejected them before processing.
+*/
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy is used by NewClient when WithRetryPolicy is not given
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, BaseDelay: 250 * time.Millisecond, MaxDelay: 5 * time.Second}
+}
+
+// retryable reports whether the request may be sent again after the response status (0 for a transport error)
+func (p RetryPolicy) retryable(req *http.Request, statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case 0, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent(req)
+	}
+	return false
+}
+
+// backoff returns the delay before the attempt (the first attempt is 1)
+func (p RetryPolicy) backoff(attempt int, header http.Header, now time.Time) time.Duration {
+	if delay, ok := retryAfter(header, now); ok {
+		if p.MaxDelay > 0 && delay > p.MaxDelay {
+			return p.MaxDelay
+		}
+		return delay
+	}
+	delay := p.BaseDelay
+	for i := 2; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// equal jitter: half of the delay is fixed, the other half is random
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// idempotent follows the net/http rules: safe methods, PUT, DELETE and requests with an idempotency key
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	if req.Header == nil {
+		return false
+	}
+	_, key := req.Header["Idempotency-Key"]
+	_, xKey := req.Header["X-Idempotency-Key"]
+	return key || xKey
+}
+
+// retryAfter parses the Retry-After header in seconds or HTTP-date format
+func retryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleep waits for the delay or until ctx is done
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
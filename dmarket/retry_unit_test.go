@@ -0,0 +1,80 @@
+package dmarket
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy_retryable(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	get, _ := http.NewRequest(http.MethodGet, "/", http.NoBody)
+	post, _ := http.NewRequest(http.MethodPost, "/", http.NoBody)
+	keyed, _ := http.NewRequest(http.MethodPatch, "/", http.NoBody)
+	keyed.Header.Set("Idempotency-Key", "key")
+	tests := []struct {
+		name       string
+		req        *http.Request
+		statusCode int
+		want       bool
+	}{
+		{name: "GET 429", req: get, statusCode: http.StatusTooManyRequests, want: true},
+		{name: "GET 503", req: get, statusCode: http.StatusServiceUnavailable, want: true},
+		{name: "GET transport error", req: get, statusCode: 0, want: true},
+		{name: "GET 400", req: get, statusCode: http.StatusBadRequest, want: false},
+		{name: "GET 501", req: get, statusCode: http.StatusNotImplemented, want: false},
+		{name: "POST 429", req: post, statusCode: http.StatusTooManyRequests, want: true},
+		{name: "POST 500", req: post, statusCode: http.StatusInternalServerError, want: false},
+		{name: "POST transport error", req: post, statusCode: 0, want: false},
+		{name: "PATCH with idempotency key 502", req: keyed, statusCode: http.StatusBadGateway, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, policy.retryable(tt.req, tt.statusCode))
+		})
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	now := time.Unix(1633697260, 0)
+	patient := RetryPolicy{MaxAttempts: 10, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Minute}
+	t.Run("exponential with jitter", func(t *testing.T) {
+		for attempt, max := range map[int]time.Duration{
+			2: 100 * time.Millisecond,
+			3: 200 * time.Millisecond,
+			4: 400 * time.Millisecond,
+			8: time.Second,
+		} {
+			for i := 0; i < 50; i++ {
+				delay := policy.backoff(attempt, http.Header{}, now)
+				require.GreaterOrEqual(t, delay, max/2)
+				require.LessOrEqual(t, delay, max)
+			}
+		}
+	})
+	t.Run("Retry-After seconds", func(t *testing.T) {
+		header := http.Header{"Retry-After": {"3"}}
+		require.Equal(t, 3*time.Second, patient.backoff(2, header, now))
+	})
+	t.Run("Retry-After date", func(t *testing.T) {
+		header := http.Header{"Retry-After": {now.Add(2 * time.Second).UTC().Format(http.TimeFormat)}}
+		require.Equal(t, 2*time.Second, patient.backoff(2, header, now))
+	})
+	t.Run("long Retry-After is capped", func(t *testing.T) {
+		require.Equal(t, time.Second, policy.backoff(2, http.Header{"Retry-After": {"120"}}, now))
+		header := http.Header{"Retry-After": {now.Add(time.Hour).UTC().Format(http.TimeFormat)}}
+		require.Equal(t, time.Second, policy.backoff(2, header, now))
+		require.Equal(t, 2*time.Minute, RetryPolicy{}.backoff(2, http.Header{"Retry-After": {"120"}}, now))
+	})
+	t.Run("Retry-After in the past", func(t *testing.T) {
+		header := http.Header{"Retry-After": {now.Add(-time.Minute).UTC().Format(http.TimeFormat)}}
+		require.Equal(t, time.Duration(0), policy.backoff(2, header, now))
+	})
+	t.Run("wrong Retry-After", func(t *testing.T) {
+		delay := policy.backoff(2, http.Header{"Retry-After": {"soon"}}, now)
+		require.LessOrEqual(t, delay, 100*time.Millisecond)
+	})
+}
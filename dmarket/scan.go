@@ -0,0 +1,166 @@
+package dmarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrScanParams indicates an incorrect ScanParams value
+var ErrScanParams = errors.New("incorrect scan params")
+
+/*
+ScanParams sets up Items.Scan
+
+	PriceFrom, PriceTo - the scanned price range in cents, PriceTo is required
+	Bands              - count of the price bands the range is split into first, 8 by default
+	Workers            - count of the bands paginated concurrently, 4 by default
+	MaxBandTotal       - the band with more items is split in two, 5000 by default
+*/
+type ScanParams struct {
+	PriceFrom, PriceTo int
+	Bands              int
+	Workers            int
+	MaxBandTotal       int
+}
+
+func (p ScanParams) withDefaults() (ScanParams, error) {
+	if p.PriceFrom < 0 || p.PriceTo <= p.PriceFrom {
+		return p, fmt.Errorf("%w [priceFrom %d priceTo %d] => priceFrom >= 0 && priceTo > priceFrom",
+			ErrIncorrectPriceRange, p.PriceFrom, p.PriceTo)
+	}
+	if p.Bands < 0 || p.Workers < 0 || p.MaxBandTotal < 0 {
+		return p, fmt.Errorf("%w [bands %d workers %d maxBandTotal %d] => must not be negative",
+			ErrScanParams, p.Bands, p.Workers, p.MaxBandTotal)
+	}
+	if p.Bands == 0 {
+		p.Bands = 8
+	}
+	if p.Workers == 0 {
+		p.Workers = 4
+	}
+	if p.MaxBandTotal == 0 {
+		p.MaxBandTotal = 5000
+	}
+	return p, nil
+}
+
+// band is the inclusive price range of Items.Scan
+type band struct {
+	from, to int
+}
+
+// split returns n bands covering the range, the neighbour bands share the edge price
+func (b band) split(n int) []band {
+	if width := b.to - b.from; n > width {
+		n = width
+	}
+	if n < 2 {
+		return []band{b}
+	}
+	bands := make([]band, 0, n)
+	from := b.from
+	for k := 1; k <= n; k++ {
+		to := b.from + (b.to-b.from)*k/n
+		bands = append(bands, band{from: from, to: to})
+		from = to
+	}
+	return bands
+}
+
+/*
+Scan gets all objects available on the Dmarket exchange in the price range much faster than the single cursor.
+
+The price range is split into the bands which are paginated concurrently, the requests are still limited
+by the rate limits of the client. The band which total is over MaxBandTotal is split in two before the pagination.
+The objects on the band edges are deduplicated by Object.ItemID.
+
+The scan stops on the first error, the options are the same as of GetAllItemsFromDmarket
+except ItemsPriceRange which is set by the bands.
+*/
+func (i Items) Scan(ctx context.Context, params ScanParams, options ...Options) ([]Object, error) {
+	params, err := params.withDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("api (items): scan error: %w", err)
+	}
+	query, err := i.Query(options...)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		seen    = make(map[string]bool)
+		objects []Object
+		scanErr error
+		workers = make(chan struct{}, params.Workers)
+	)
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if scanErr == nil {
+			scanErr = err
+			cancel()
+		}
+	}
+	collect := func(page []Object) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, object := range page {
+			if !seen[object.ItemID] {
+				seen[object.ItemID] = true
+				objects = append(objects, object)
+			}
+		}
+	}
+
+	var scan func(b band)
+	scan = func(b band) {
+		defer wg.Done()
+		select {
+		case workers <- struct{}{}:
+		case <-ctx.Done():
+			fail(ctx.Err())
+			return
+		}
+		defer func() { <-workers }()
+
+		q := query
+		q.priceFrom, q.priceTo, q.cursor = b.from, b.to, ""
+		page := i.GetItems(ctx, marketItems, q)
+		if page.Error != nil {
+			fail(page.Error)
+			return
+		}
+		if halves := b.split(2); page.Total.Items > params.MaxBandTotal && len(halves) == 2 {
+			wg.Add(2)
+			go scan(halves[0])
+			go scan(halves[1])
+			return
+		}
+		for {
+			collect(page.Objects)
+			if len(page.Objects) == 0 || page.Cursor == "" || page.Cursor == q.cursor {
+				return
+			}
+			q = q.WithCursor(page.Cursor)
+			if page = i.GetItems(ctx, marketItems, q); page.Error != nil {
+				fail(page.Error)
+				return
+			}
+		}
+	}
+	for _, b := range (band{from: params.PriceFrom, to: params.PriceTo}).split(params.Bands) {
+		wg.Add(1)
+		go scan(b)
+	}
+	wg.Wait()
+	if scanErr != nil {
+		return nil, fmt.Errorf("api (items): scan error: %w", scanErr)
+	}
+	return objects, nil
+}
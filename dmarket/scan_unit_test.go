@@ -0,0 +1,39 @@
+package dmarket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBand_split(t *testing.T) {
+	require.Equal(t, []band{{0, 25}, {25, 50}, {50, 75}, {75, 100}}, band{0, 100}.split(4))
+	require.Equal(t, []band{{10, 11}, {11, 12}, {12, 13}}, band{10, 13}.split(8))
+	require.Equal(t, []band{{10, 11}}, band{10, 11}.split(2))
+	require.Equal(t, []band{{0, 100}}, band{0, 100}.split(1))
+}
+
+func TestItems_Scan_params(t *testing.T) {
+	tests := []struct {
+		name   string
+		params ScanParams
+		err    error
+	}{
+		{name: "ERR:no priceTo", params: ScanParams{}, err: ErrIncorrectPriceRange},
+		{name: "ERR:priceFrom<0", params: ScanParams{PriceFrom: -1, PriceTo: 10}, err: ErrIncorrectPriceRange},
+		{name: "ERR:negative bands", params: ScanParams{PriceTo: 10, Bands: -1}, err: ErrScanParams},
+		{name: "ERR:negative workers", params: ScanParams{PriceTo: 10, Workers: -1}, err: ErrScanParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{}
+			_, err := NewExchange(r).Items.Scan(context.Background(), tt.params)
+			require.ErrorIs(t, err, tt.err)
+			require.Empty(t, r.endpoint)
+		})
+	}
+	params, err := ScanParams{PriceTo: 10}.withDefaults()
+	require.NoError(t, err)
+	require.Equal(t, ScanParams{PriceTo: 10, Bands: 8, Workers: 4, MaxBandTotal: 5000}, params)
+}
@@ -0,0 +1,235 @@
+package dmarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	createTargets = "/marketplace-api/v1/user-targets/create"
+	userTargets   = "/marketplace-api/v1/user-targets?"
+	deleteTargets = "/marketplace-api/v1/user-targets/delete"
+)
+
+// ErrTargetAmount indicates an attempt to create a target that buys no items
+var ErrTargetAmount = errors.New("target amount must be greater than zero")
+
+// TargetStatus is a status of the user target (buy order)
+type TargetStatus string
+
+const (
+	TargetStatusActive   TargetStatus = "TargetStatusActive"
+	TargetStatusInactive TargetStatus = "TargetStatusInactive"
+)
+
+// Targets is a service structure for interacting with dmarket user targets (buy orders) API endpoints
+type Targets struct {
+	client Requester
+}
+
+/*
+TargetAttributes narrows the items that a target is allowed to buy
+
+	FloatPartValue - float value range of the item, like "0.00-0.07" (CS:GO)
+	PaintSeed      - paint seed (pattern) of the item (CS:GO)
+	Phase          - doppler phase of the knife, like "phase-2" (CS:GO)
+*/
+type TargetAttributes struct {
+	FloatPartValue string `json:"floatPartValue,omitempty"`
+	PaintSeed      int    `json:"paintSeed,omitempty"`
+	Phase          string `json:"phase,omitempty"`
+}
+
+// CreateTarget describes a new buy order for the items with the Title
+type CreateTarget struct {
+	Title  string           `json:"Title"`
+	Amount int              `json:"Amount"`
+	Price  MarketplacePrice `json:"Price"`
+	Attrs  TargetAttributes `json:"Attrs"`
+}
+
+type TargetAttribute struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+// Target represent the user target returned by Dmarket
+type Target struct {
+	TargetID   string            `json:"TargetID"`
+	Title      string            `json:"Title"`
+	Amount     int64             `json:"Amount,string"`
+	Status     TargetStatus      `json:"Status"`
+	GameID     string            `json:"GameID"`
+	Price      MarketplacePrice  `json:"Price"`
+	Attributes []TargetAttribute `json:"Attributes"`
+}
+
+type CreateTargetResult struct {
+	CreateTarget CreateTarget      `json:"CreateTarget"`
+	TargetID     string            `json:"TargetID"`
+	Successful   bool              `json:"Successful"`
+	Error        *MarketplaceError `json:"Error"`
+}
+
+type DeleteTargetResult struct {
+	DeleteTarget struct {
+		TargetID string `json:"TargetID"`
+	} `json:"DeleteTarget"`
+	Successful bool              `json:"Successful"`
+	Error      *MarketplaceError `json:"Error"`
+}
+
+type CreateTargetsResponse struct {
+	Result []CreateTargetResult `json:"Result"`
+}
+
+type DeleteTargetsResponse struct {
+	Result []DeleteTargetResult `json:"Result"`
+}
+
+type UserTargetsResponse struct {
+	Items  []Target `json:"Items"`
+	Total  int64    `json:"Total,string"`
+	Cursor string   `json:"Cursor"`
+}
+
+// ListTargetsParams sets the filter and the page of the user targets list
+type ListTargetsParams struct {
+	GameID string
+	Status TargetStatus
+	Limit  int
+	Cursor string
+}
+
+// Err reports the targets that Dmarket did not create, see CreateOffersResponse.Err
+func (r CreateTargetsResponse) Err() error {
+	var errs error
+	for _, result := range r.Result {
+		if !result.Successful {
+			errs = multierror.Append(errs, fmt.Errorf("target %s: %w", result.CreateTarget.Title, failure(result.Error)))
+		}
+	}
+	return errs
+}
+
+// Err reports the targets that Dmarket did not delete, see CreateOffersResponse.Err
+func (r DeleteTargetsResponse) Err() error {
+	var errs error
+	for _, result := range r.Result {
+		if !result.Successful {
+			errs = multierror.Append(errs, fmt.Errorf("target %s: %w", result.DeleteTarget.TargetID, failure(result.Error)))
+		}
+	}
+	return errs
+}
+
+/*
+Create places buy orders for the game items
+
+https://api.dmarket.com/marketplace-api/v1/user-targets/create
+
+The returned error reports only validation, request, HTTP and decoding failures,
+per-target failures are available with CreateTargetsResponse.Err.
+*/
+func (t Targets) Create(ctx context.Context, gameID string, targets ...CreateTarget) (*CreateTargetsResponse, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("api (targets): create targets error: %w", ErrEmptyBatch)
+	}
+	for _, target := range targets {
+		if target.Amount <= 0 {
+			return nil, fmt.Errorf("api (targets): create targets error: %w [title %s amount %d]",
+				ErrTargetAmount, target.Title, target.Amount)
+		}
+	}
+	resp := new(CreateTargetsResponse)
+	err := sendJSON(ctx, t.client.PostContext, createTargets, struct {
+		GameID  string         `json:"GameID"`
+		Targets []CreateTarget `json:"Targets"`
+	}{gameID, targets}, resp)
+	if err != nil {
+		return nil, fmt.Errorf("api (targets): create targets error: %w", err)
+	}
+	return resp, nil
+}
+
+/*
+List gets one page of the user targets
+
+https://api.dmarket.com/marketplace-api/v1/user-targets?GameID={gameID}&BasicFilters.Status={status}&Limit={limit}&Cursor={cursor}
+
+The next page is requested with the UserTargetsResponse.Cursor, an empty cursor means that there are no more pages.
+*/
+func (t Targets) List(ctx context.Context, params ListTargetsParams) (*UserTargetsResponse, error) {
+	query := url.Values{
+		"GameID": {params.GameID},
+	}
+	if params.Status != "" {
+		query.Set("BasicFilters.Status", string(params.Status))
+	}
+	if params.Limit > 0 {
+		query.Set("Limit", strconv.Itoa(params.Limit))
+	}
+	if params.Cursor != "" {
+		query.Set("Cursor", params.Cursor)
+	}
+	resp, err := t.client.GetContext(ctx, userTargets+query.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("api (targets): list targets request error: %w", err)
+	}
+	targets := new(UserTargetsResponse)
+	err = decodeResponse(resp, targets)
+	if err != nil {
+		return nil, fmt.Errorf("api (targets): list targets error: %w", err)
+	}
+	return targets, nil
+}
+
+// ListAll follows the cursor starting from params.Cursor and gets all user targets
+func (t Targets) ListAll(ctx context.Context, params ListTargetsParams) ([]Target, error) {
+	var targets []Target
+	for {
+		page, err := t.List(ctx, params)
+		if err != nil {
+			return targets, err
+		}
+		targets = append(targets, page.Items...)
+		if page.Cursor == "" || len(page.Items) == 0 {
+			return targets, nil
+		}
+		params.Cursor = page.Cursor
+	}
+}
+
+/*
+Delete removes user targets by IDs
+
+https://api.dmarket.com/marketplace-api/v1/user-targets/delete
+
+The returned error reports only request, HTTP and decoding failures,
+per-target failures are available with DeleteTargetsResponse.Err.
+*/
+func (t Targets) Delete(ctx context.Context, targetIDs ...string) (*DeleteTargetsResponse, error) {
+	if len(targetIDs) == 0 {
+		return nil, fmt.Errorf("api (targets): delete targets error: %w", ErrEmptyBatch)
+	}
+	type deleteTarget struct {
+		TargetID string `json:"TargetID"`
+	}
+	targets := make([]deleteTarget, 0, len(targetIDs))
+	for _, id := range targetIDs {
+		targets = append(targets, deleteTarget{TargetID: id})
+	}
+	resp := new(DeleteTargetsResponse)
+	err := sendJSON(ctx, t.client.PostContext, deleteTargets, struct {
+		Targets []deleteTarget `json:"Targets"`
+	}{targets}, resp)
+	if err != nil {
+		return nil, fmt.Errorf("api (targets): delete targets error: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,54 @@
+package dmarket
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTargets_List(t *testing.T) {
+	t.Run("query params", func(t *testing.T) {
+		r := &recorder{response: respond(http.StatusOK, `{"Items":[{"TargetID":"id","Amount":"2"}],"Total":"1","Cursor":"next"}`)}
+		resp, err := Targets{client: r}.List(context.Background(), ListTargetsParams{GameID: "a8db", Status: TargetStatusActive, Limit: 10, Cursor: "cursor"})
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(r.endpoint, userTargets))
+		query, err := url.ParseQuery(strings.TrimPrefix(r.endpoint, userTargets))
+		require.NoError(t, err)
+		require.Equal(t, "a8db", query.Get("GameID"))
+		require.Equal(t, string(TargetStatusActive), query.Get("BasicFilters.Status"))
+		require.Equal(t, "10", query.Get("Limit"))
+		require.Equal(t, "cursor", query.Get("Cursor"))
+		require.Equal(t, int64(2), resp.Items[0].Amount)
+		require.Equal(t, "next", resp.Cursor)
+	})
+	t.Run("skip empty params", func(t *testing.T) {
+		r := &recorder{response: respond(http.StatusOK, `{"Items":[],"Total":"0","Cursor":""}`)}
+		_, err := Targets{client: r}.List(context.Background(), ListTargetsParams{GameID: "a8db"})
+		require.NoError(t, err)
+		require.Equal(t, userTargets+"GameID=a8db", r.endpoint)
+	})
+}
+
+func TestTargets_Create(t *testing.T) {
+	t.Run("request body", func(t *testing.T) {
+		r := &recorder{response: respond(http.StatusOK, `{"Result":[]}`)}
+		_, err := Targets{client: r}.Create(context.Background(), "a8db", CreateTarget{
+			Title:  "AK-47 | Redline (Field-Tested)",
+			Amount: 1,
+			Price:  MarketplacePrice{Currency: "USD", Amount: 1000},
+			Attrs:  TargetAttributes{FloatPartValue: "0.15-0.18"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.MethodPost, r.method)
+		require.JSONEq(t, `{"GameID":"a8db","Targets":[{"Title":"AK-47 | Redline (Field-Tested)","Amount":1,`+
+			`"Price":{"Currency":"USD","Amount":10.00},"Attrs":{"floatPartValue":"0.15-0.18"}}]}`, string(r.body))
+	})
+	t.Run("error: amount", func(t *testing.T) {
+		_, err := Targets{}.Create(context.Background(), "a8db", CreateTarget{Title: "title"})
+		require.ErrorIs(t, err, ErrTargetAmount)
+	})
+}
@@ -0,0 +1,140 @@
+package dmarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrWatchInterval indicates the poll interval of Items.Watch less than or equal to zero
+var ErrWatchInterval = errors.New("watch poll interval must be greater than zero")
+
+// EventType is the type of the market change found by Items.Watch
+type EventType string
+
+const (
+	// EventAdded is the object listed since the previous snapshot
+	EventAdded EventType = "added"
+	// EventRemoved is the object sold or delisted since the previous snapshot
+	EventRemoved EventType = "removed"
+	// EventPriceChanged is the object which price changed since the previous snapshot
+	EventPriceChanged EventType = "price_changed"
+)
+
+/*
+Event is the market change found by Items.Watch
+
+	Object             - the object of the current snapshot, the object of the previous one for EventRemoved
+	OldPrice, NewPrice - the prices of EventPriceChanged
+	Error              - the error of the poll, the snapshot is not changed then
+*/
+type Event struct {
+	Type     EventType
+	Object   Object
+	OldPrice Price
+	NewPrice Price
+	Error    error
+}
+
+/*
+Watch polls the objects available on the Dmarket exchange with the options every interval
+and sends the changes between the snapshots keyed by Object.ItemID to the events channel.
+
+The first poll is the baseline snapshot, so the events are sent from the second poll.
+The events of the poll are sent in the order of the objects, the removed objects go last.
+The error of the poll is sent as the Event with the Error, the next poll is compared with the last snapshot.
+The events channel is closed when ctx is done.
+
+The options are the same as of GetAllItemsFromDmarket.
+*/
+func (i Items) Watch(ctx context.Context, interval time.Duration, options ...Options) (events chan Event, err error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("api (items): watch error: %w [interval %s]", ErrWatchInterval, interval)
+	}
+	if _, err = i.Query(options...); err != nil {
+		return nil, err
+	}
+	events = make(chan Event, 1)
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		var previous []Object
+		baseline := true
+		for {
+			current, err := i.snapshot(ctx, options...)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				if !send(ctx, events, Event{Error: err}) {
+					return
+				}
+			case baseline:
+				previous, baseline = current, false
+			default:
+				for _, event := range diff(previous, current) {
+					if !send(ctx, events, event) {
+						return
+					}
+				}
+				previous = current
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return events, nil
+}
+
+// snapshot gets all pages of the poll
+func (i Items) snapshot(ctx context.Context, options ...Options) ([]Object, error) {
+	pager, err := i.PagerFromDmarket(options...)
+	if err != nil {
+		return nil, err
+	}
+	return pager.CollectAll(ctx, 0)
+}
+
+// send sends the event unless ctx is done
+func send(ctx context.Context, events chan<- Event, event Event) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case events <- event:
+		return true
+	}
+}
+
+// diff returns the events of the changes between the snapshots
+func diff(previous, current []Object) []Event {
+	var events []Event
+	old := make(map[string]Object, len(previous))
+	for _, object := range previous {
+		old[object.ItemID] = object
+	}
+	seen := make(map[string]bool, len(current))
+	for _, object := range current {
+		if seen[object.ItemID] {
+			continue
+		}
+		seen[object.ItemID] = true
+		was, ok := old[object.ItemID]
+		switch {
+		case !ok:
+			events = append(events, Event{Type: EventAdded, Object: object, NewPrice: object.Price})
+		case was.Price != object.Price:
+			events = append(events, Event{Type: EventPriceChanged, Object: object, OldPrice: was.Price, NewPrice: object.Price})
+		}
+	}
+	for _, object := range previous {
+		if !seen[object.ItemID] {
+			events = append(events, Event{Type: EventRemoved, Object: object, OldPrice: object.Price})
+		}
+	}
+	return events
+}
@@ -0,0 +1,30 @@
+package dmarket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func watchObject(id string, price Cents) Object {
+	return Object{ItemID: id, Price: Price{Usd: price}}
+}
+
+func TestDiff(t *testing.T) {
+	previous := []Object{watchObject("1", 100), watchObject("2", 200), watchObject("3", 300)}
+	current := []Object{watchObject("2", 150), watchObject("3", 300), watchObject("4", 400), watchObject("4", 400)}
+	require.Equal(t, []Event{
+		{Type: EventPriceChanged, Object: current[0], OldPrice: Price{Usd: 200}, NewPrice: Price{Usd: 150}},
+		{Type: EventAdded, Object: current[2], NewPrice: Price{Usd: 400}},
+		{Type: EventRemoved, Object: previous[0], OldPrice: Price{Usd: 100}},
+	}, diff(previous, current))
+	require.Empty(t, diff(current, current))
+}
+
+func TestItems_Watch_params(t *testing.T) {
+	_, err := NewExchange(&recorder{}).Items.Watch(context.Background(), 0)
+	require.ErrorIs(t, err, ErrWatchInterval)
+	_, err = NewExchange(&recorder{}).Items.Watch(context.Background(), 1, ItemsLimitPerRequest(0))
+	require.ErrorIs(t, err, ErrLimitPerRequest)
+}
@@ -0,0 +1,77 @@
+package account
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeesParams struct {
+	GameID string `form:"gameId" binding:"required"`
+	Offset int    `form:"offset" binding:"gte=0"`
+	Limit  int    `form:"limit" binding:"required,gte=1,lte=100"`
+}
+
+// Account is a mock user account which balance can be changed between requests
+type Account struct {
+	mu      sync.Mutex
+	balance dmarket.Balance
+	user    dmarket.User
+	fees    dmarket.FeesResponse
+}
+
+// MustReturnSuccess creates an Account with the balance, the user profile and the fee schedule
+func MustReturnSuccess(balance dmarket.Balance, user dmarket.User, fees dmarket.FeesResponse) *Account {
+	return &Account{balance: balance, user: user, fees: fees}
+}
+
+// SetBalance changes the balance returned by the next balance requests
+func (a *Account) SetBalance(balance dmarket.Balance) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.balance = balance
+}
+
+// Balance handles GET /account/v1/balance
+func (a *Account) Balance() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodGet, "/account/v1/balance", func(context *gin.Context) {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		context.JSON(http.StatusOK, a.balance)
+	})
+}
+
+// User handles GET /account/v1/user
+func (a *Account) User() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodGet, "/account/v1/user", func(context *gin.Context) {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		context.JSON(http.StatusOK, a.user)
+	})
+}
+
+// Fees handles GET /exchange/v1/customized-fees with offset pagination of the reduced fees
+func (a *Account) Fees() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodGet, "/exchange/v1/customized-fees", func(context *gin.Context) {
+		var params FeesParams
+		if err := context.ShouldBindQuery(&params); err != nil {
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			return
+		}
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		resp := dmarket.FeesResponse{DefaultFee: a.fees.DefaultFee, Total: len(a.fees.ReducedFees), ReducedFees: []dmarket.ReducedFee{}}
+		if params.Offset < len(a.fees.ReducedFees) {
+			end := params.Offset + params.Limit
+			if end > len(a.fees.ReducedFees) {
+				end = len(a.fees.ReducedFees)
+			}
+			resp.ReducedFees = a.fees.ReducedFees[params.Offset:end]
+		}
+		context.JSON(http.StatusOK, &resp)
+	})
+}
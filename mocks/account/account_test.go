@@ -0,0 +1,50 @@
+package account_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/account"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+func TestAccount_Fees(t *testing.T) {
+	mock := account.MustReturnSuccess(dmarket.Balance{}, dmarket.User{}, dmarket.FeesResponse{
+		DefaultFee:  dmarket.Fee{Fraction: 0.1, MinAmount: 1},
+		ReducedFees: []dmarket.ReducedFee{{Title: "a", Fraction: 0.05}, {Title: "b", Fraction: 0.02}},
+	})
+	router := gin.New()
+	router.Handle(mock.Fees().Endpoint())
+	cases := []struct {
+		name           string
+		query          string
+		wantHTTPCode   int
+		wantBodyString string
+	}{
+		{name: "success", query: "gameId=a8db&limit=100", wantHTTPCode: http.StatusOK, wantBodyString: `"title":"b"`},
+		{name: "success: offset", query: "gameId=a8db&offset=1&limit=1", wantHTTPCode: http.StatusOK, wantBodyString: `"reducedFees":[{"title":"b"`},
+		{name: "success: offset > total", query: "gameId=a8db&offset=5&limit=1", wantHTTPCode: http.StatusOK, wantBodyString: `"reducedFees":[]`},
+		{name: "error: no gameId", query: "limit=100", wantHTTPCode: http.StatusBadRequest, wantBodyString: `"error":"BadRequest"`},
+		{name: "error: limit > 100", query: "gameId=a8db&limit=101", wantHTTPCode: http.StatusBadRequest, wantBodyString: `"error":"BadRequest"`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/exchange/v1/customized-fees?"+tc.query, nil)
+			require.NoError(t, err)
+			router.ServeHTTP(w, req)
+			require.Equal(t, tc.wantHTTPCode, w.Code)
+			require.Contains(t, w.Body.String(), tc.wantBodyString)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package buy
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/bxcodec/faker/v3"
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Params struct {
+	Offers []struct {
+		OfferID string `json:"offerId" binding:"required"`
+		Price   struct {
+			Amount   string `json:"amount" binding:"required"`
+			Currency string `json:"currency" binding:"required,eq=USD"`
+		} `json:"price"`
+		Type string `json:"type" binding:"omitempty,oneof=dmarket p2p"`
+	} `json:"offers" binding:"required,min=1,dive"`
+}
+
+// EndpointBehaviorOK is a market of offers which can be bought only once and only at the actual price
+type EndpointBehaviorOK struct {
+	mu     sync.Mutex
+	offers map[string]int64
+	// balance limits the total price of the purchase when it is not negative
+	balance int64
+}
+
+// MustReturnSuccess creates a market with the offers prices in cents by offer ID
+func MustReturnSuccess(offers map[string]int64) *EndpointBehaviorOK {
+	e := &EndpointBehaviorOK{offers: make(map[string]int64, len(offers)), balance: -1}
+	for id, price := range offers {
+		e.offers[id] = price
+	}
+	return e
+}
+
+// WithBalance sets the user balance in cents, a purchase of the greater total is answered with InsufficientFunds
+func (e *EndpointBehaviorOK) WithBalance(balance int64) *EndpointBehaviorOK {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.balance = balance
+	return e
+}
+
+func (e *EndpointBehaviorOK) Endpoint() (httpMethod string, relativePath string, handler gin.HandlerFunc) {
+	return http.MethodPatch, "/exchange/v1/offers-buy", func(context *gin.Context) {
+		var params Params
+		if err := context.ShouldBindJSON(&params); err != nil {
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			return
+		}
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if e.balance >= 0 {
+			var total int64
+			for _, offer := range params.Offers {
+				amount, _ := strconv.ParseInt(offer.Price.Amount, 10, 64)
+				total += amount
+			}
+			if total > e.balance {
+				common.WriteError(context, http.StatusBadRequest, "InsufficientFunds",
+					fmt.Sprintf("not enough money on balance: %d < %d", e.balance, total))
+				return
+			}
+		}
+		statuses := make(map[string]gin.H, len(params.Offers))
+		for _, offer := range params.Offers {
+			price, ok := e.offers[offer.OfferID]
+			switch {
+			case !ok:
+				statuses[offer.OfferID] = gin.H{"status": dmarket.BuyStatusAlreadySold}
+			case strconv.FormatInt(price, 10) != offer.Price.Amount:
+				statuses[offer.OfferID] = gin.H{"status": dmarket.BuyStatusPriceChanged}
+			default:
+				if e.balance >= 0 {
+					e.balance -= price
+				}
+				delete(e.offers, offer.OfferID)
+				statuses[offer.OfferID] = gin.H{"status": dmarket.BuyStatusBought}
+			}
+		}
+		context.JSON(http.StatusOK, gin.H{
+			"orderId":        faker.UUIDHyphenated(),
+			"status":         "TxPending",
+			"txId":           faker.UUIDDigit(),
+			"dmOffersStatus": statuses,
+		})
+	}
+}
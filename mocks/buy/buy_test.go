@@ -0,0 +1,117 @@
+package buy_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/defernest/dmarket-go/mocks/buy"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+func TestMustReturnSuccess(t *testing.T) {
+	router := gin.New()
+	router.Handle(buy.MustReturnSuccess(map[string]int64{"1": 100}).Endpoint())
+	cases := []struct {
+		name           string
+		body           string
+		wantHTTPCode   int
+		wantBodyString string
+	}{
+		{
+			name:           "success: price changed",
+			body:           `{"offers":[{"offerId":"1","price":{"amount":"99","currency":"USD"}}]}`,
+			wantHTTPCode:   http.StatusOK,
+			wantBodyString: `"1":{"status":"PriceChanged"}`,
+		},
+		{
+			name:           "success: bought",
+			body:           `{"offers":[{"offerId":"1","price":{"amount":"100","currency":"USD"}}]}`,
+			wantHTTPCode:   http.StatusOK,
+			wantBodyString: `"1":{"status":"Bought"}`,
+		},
+		{
+			name:           "success: already sold",
+			body:           `{"offers":[{"offerId":"1","price":{"amount":"100","currency":"USD"}}]}`,
+			wantHTTPCode:   http.StatusOK,
+			wantBodyString: `"1":{"status":"AlreadySold"}`,
+		},
+		{
+			name:           "error: currency != USD",
+			body:           `{"offers":[{"offerId":"1","price":{"amount":"100","currency":"DMC"}}]}`,
+			wantHTTPCode:   http.StatusBadRequest,
+			wantBodyString: `"error":"BadRequest"`,
+		},
+		{
+			name:           "error: unknown offer type",
+			body:           `{"offers":[{"offerId":"1","price":{"amount":"100","currency":"USD"},"type":"auction"}]}`,
+			wantHTTPCode:   http.StatusBadRequest,
+			wantBodyString: `"error":"BadRequest"`,
+		},
+		{
+			name:           "error: no offers",
+			body:           `{"offers":[]}`,
+			wantHTTPCode:   http.StatusBadRequest,
+			wantBodyString: `"error":"BadRequest"`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPatch, "/exchange/v1/offers-buy", strings.NewReader(tc.body))
+			require.NoError(t, err)
+			router.ServeHTTP(w, req)
+			require.Equal(t, tc.wantHTTPCode, w.Code)
+			require.Contains(t, w.Body.String(), tc.wantBodyString)
+		})
+	}
+}
+
+func TestEndpointBehaviorOK_WithBalance(t *testing.T) {
+	router := gin.New()
+	router.Handle(buy.MustReturnSuccess(map[string]int64{"1": 100, "2": 200}).WithBalance(250).Endpoint())
+	cases := []struct {
+		name           string
+		body           string
+		wantHTTPCode   int
+		wantBodyString string
+	}{
+		{
+			name:           "error: insufficient funds",
+			body:           `{"offers":[{"offerId":"1","price":{"amount":"100","currency":"USD"}},{"offerId":"2","price":{"amount":"200","currency":"USD"}}]}`,
+			wantHTTPCode:   http.StatusBadRequest,
+			wantBodyString: `"error":"InsufficientFunds"`,
+		},
+		{
+			name:           "success: bought",
+			body:           `{"offers":[{"offerId":"2","price":{"amount":"200","currency":"USD"}}]}`,
+			wantHTTPCode:   http.StatusOK,
+			wantBodyString: `"2":{"status":"Bought"}`,
+		},
+		{
+			name:           "error: balance is spent",
+			body:           `{"offers":[{"offerId":"1","price":{"amount":"100","currency":"USD"}}]}`,
+			wantHTTPCode:   http.StatusBadRequest,
+			wantBodyString: `"error":"InsufficientFunds"`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPatch, "/exchange/v1/offers-buy", strings.NewReader(tc.body))
+			require.NoError(t, err)
+			router.ServeHTTP(w, req)
+			require.Equal(t, tc.wantHTTPCode, w.Code)
+			require.Contains(t, w.Body.String(), tc.wantBodyString)
+		})
+	}
+}
@@ -2,14 +2,56 @@ package common
 
 import (
 	"fmt"
-	"github.com/defernest/dmarket-go/dmarket"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// grpcCodes maps HTTP statuses to the gRPC codes that Dmarket reports in the error body
+var grpcCodes = map[int]int{
+	http.StatusBadRequest:          3,
+	http.StatusUnauthorized:        16,
+	http.StatusForbidden:           7,
+	http.StatusNotFound:            5,
+	http.StatusConflict:            6,
+	http.StatusTooManyRequests:     8,
+	http.StatusInternalServerError: 13,
+	http.StatusNotImplemented:      12,
+	http.StatusServiceUnavailable:  14,
+	http.StatusGatewayTimeout:      4,
+}
+
+// ErrorCode returns the default Dmarket error code of the HTTP status, like "NotFound" for 404
+func ErrorCode(status int) string {
+	return strings.ReplaceAll(http.StatusText(status), " ", "")
+}
+
+/*
+WriteError writes the Dmarket error body with the HTTP status
+
+	{"error":"InsufficientFunds","code":3,"message":"not enough money","details":[]}
+
+An empty code is replaced with the ErrorCode of the status.
+*/
+func WriteError(context *gin.Context, status int, code, message string) {
+	if code == "" {
+		code = ErrorCode(status)
+	}
+	grpcCode, ok := grpcCodes[status]
+	if !ok {
+		grpcCode = 2
+	}
+	context.JSON(status, gin.H{
+		"error":   code,
+		"code":    grpcCode,
+		"message": message,
+		"details": []gin.H{},
+	})
+}
+
 type EndpointBehavior struct {
 	httpMethod   string
 	relativePath string
@@ -60,7 +102,7 @@ func MustReturnHTTPError(method, path string, errcode int) *EndpointBehavior {
 		httpMethod:   method,
 		relativePath: path,
 		handlerFunc: func(context *gin.Context) {
-			context.String(dmarket.ErrorRepresentation{Response: dmarket.Response{StatusCode: errcode}}.String())
+			WriteError(context, errcode, "", fmt.Sprintf("%d: %s", errcode, http.StatusText(errcode)))
 		},
 	}
 }
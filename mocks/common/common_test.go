@@ -32,6 +32,34 @@ func TestNewCommonEndpoint(t *testing.T) {
 	})
 }
 
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		code     string
+		wantCode string
+		wantGRPC int
+	}{
+		{name: "default code", status: http.StatusNotFound, wantCode: "NotFound", wantGRPC: 5},
+		{name: "custom code", status: http.StatusBadRequest, code: "InsufficientFunds", wantCode: "InsufficientFunds", wantGRPC: 3},
+		{name: "unknown gRPC code", status: http.StatusTeapot, wantCode: "I'mateapot", wantGRPC: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/", func(context *gin.Context) {
+				common.WriteError(context, tt.status, tt.code, "message")
+			})
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+			router.ServeHTTP(w, req)
+			require.Equal(t, tt.status, w.Code)
+			require.JSONEq(t, fmt.Sprintf(`{"error":%q,"code":%d,"message":"message","details":[]}`, tt.wantCode, tt.wantGRPC), w.Body.String())
+		})
+	}
+}
+
 func TestMustReturnStatusOK(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -0,0 +1,36 @@
+package games
+
+import (
+	"net/http"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Catalog returns the games with the typed constants of the dmarket package, all enabled
+func Catalog() []dmarket.GameInfo {
+	catalog := make([]dmarket.GameInfo, 0, len(dmarket.Games()))
+	for _, game := range dmarket.Games() {
+		catalog = append(catalog, dmarket.GameInfo{
+			ID:                game,
+			Title:             game.String(),
+			Logo:              "https://cdn.dmarket.com/games/" + string(game) + ".png",
+			IsEnabled:         true,
+			IsDepositEnabled:  true,
+			IsWithdrawEnabled: true,
+		})
+	}
+	return catalog
+}
+
+// MustReturnSuccess handles GET /game/v1/games with the games or the Catalog when no games are given
+func MustReturnSuccess(games ...dmarket.GameInfo) *common.EndpointBehavior {
+	if len(games) == 0 {
+		games = Catalog()
+	}
+	return common.NewEndpointBehavior(http.MethodGet, "/game/v1/games", func(context *gin.Context) {
+		context.JSON(http.StatusOK, games)
+	})
+}
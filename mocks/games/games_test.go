@@ -0,0 +1,45 @@
+package games_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/games"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+func TestMustReturnSuccess(t *testing.T) {
+	cases := []struct {
+		name  string
+		games []dmarket.GameInfo
+		want  []dmarket.GameInfo
+	}{
+		{name: "success: catalog", want: games.Catalog()},
+		{name: "success: custom", games: []dmarket.GameInfo{{ID: "x", Title: "X"}}, want: []dmarket.GameInfo{{ID: "x", Title: "X"}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.New()
+			router.Handle(games.MustReturnSuccess(tc.games...).Endpoint())
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/game/v1/games", nil)
+			require.NoError(t, err)
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code)
+			var got []dmarket.GameInfo
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package history
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ClosedParams struct {
+	GameID     string `form:"GameID" binding:"required"`
+	ClosedFrom int64  `form:"ClosedFrom" binding:"required,gt=0"`
+	ClosedTo   int64  `form:"ClosedTo" binding:"required,gtefield=ClosedFrom"`
+	Limit      int    `form:"Limit" binding:"required,gte=1,lte=100"`
+	Cursor     string `form:"Cursor"`
+}
+
+// Store is an in-memory user trade history of the closed offers and targets
+type Store struct {
+	mu     sync.Mutex
+	trades []dmarket.ClosedTrade
+}
+
+// MustReturnSuccess creates a Store with the closed trades, the trades of TradeSale are the closed offers
+func MustReturnSuccess(trades ...dmarket.ClosedTrade) *Store {
+	return &Store{trades: trades}
+}
+
+// Add adds the closed trades to the history
+func (s *Store) Add(trades ...dmarket.ClosedTrade) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.trades = append(s.trades, trades...)
+}
+
+// ClosedOffers handles GET /marketplace-api/v1/user-offers/closed, the cursor is an offset of the next page
+func (s *Store) ClosedOffers() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodGet, "/marketplace-api/v1/user-offers/closed", s.closed(dmarket.TradeSale))
+}
+
+// ClosedTargets handles GET /marketplace-api/v1/user-targets/closed, the cursor is an offset of the next page
+func (s *Store) ClosedTargets() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodGet, "/marketplace-api/v1/user-targets/closed", s.closed(dmarket.TradePurchase))
+}
+
+func (s *Store) closed(kind dmarket.TradeKind) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		var params ClosedParams
+		if err := context.ShouldBindQuery(&params); err != nil {
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			return
+		}
+		offset, err := strconv.Atoi(params.Cursor)
+		if params.Cursor == "" {
+			offset, err = 0, nil
+		}
+		if err != nil || offset < 0 {
+			common.WriteError(context, http.StatusBadRequest, "", fmt.Sprintf("invalid cursor %q", params.Cursor))
+			return
+		}
+		from, to := time.Unix(params.ClosedFrom, 0), time.Unix(params.ClosedTo, 0)
+
+		s.mu.Lock()
+		var matched []dmarket.ClosedTrade
+		for _, trade := range s.trades {
+			if trade.Kind == kind && !trade.ClosedAt.Before(from) && !trade.ClosedAt.After(to) {
+				matched = append(matched, trade)
+			}
+		}
+		s.mu.Unlock()
+
+		trades := make([]gin.H, 0, params.Limit)
+		cursor := ""
+		if offset < len(matched) {
+			end := offset + params.Limit
+			if end < len(matched) {
+				cursor = strconv.Itoa(end)
+			} else {
+				end = len(matched)
+			}
+			for _, trade := range matched[offset:end] {
+				trades = append(trades, encode(trade))
+			}
+		}
+		context.JSON(http.StatusOK, gin.H{"Trades": trades, "Total": strconv.Itoa(len(matched)), "Cursor": cursor})
+	}
+}
+
+// encode returns the closed offer or target like Dmarket sends it
+func encode(trade dmarket.ClosedTrade) gin.H {
+	currency := string(trade.Price.Currency)
+	created, closed := strconv.FormatInt(trade.CreatedAt.Unix(), 10), strconv.FormatInt(trade.ClosedAt.Unix(), 10)
+	t := gin.H{
+		"AssetID": trade.AssetID,
+		"Title":   trade.Title,
+		"Price":   dmarket.MarketplacePrice{Currency: currency, Amount: int64(trade.Price.Amount)},
+		"Status":  trade.Status,
+	}
+	if trade.Kind == dmarket.TradeSale {
+		t["OfferID"], t["OfferCreatedAt"], t["OfferClosedAt"] = trade.ID, created, closed
+		t["Fee"] = dmarket.MarketplacePrice{Currency: currency, Amount: int64(trade.Fee.Amount)}
+	} else {
+		t["TargetID"], t["TargetCreatedAt"], t["TargetClosedAt"] = trade.ID, created, closed
+	}
+	return t
+}
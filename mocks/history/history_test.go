@@ -0,0 +1,62 @@
+package history_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/history"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+func TestStore(t *testing.T) {
+	mock := history.MustReturnSuccess(
+		dmarket.ClosedTrade{Kind: dmarket.TradeSale, ID: "o1", Price: dmarket.NewMoney(1000, dmarket.CurrencyUSD),
+			Fee: dmarket.NewMoney(50, dmarket.CurrencyUSD), ClosedAt: time.Unix(1000, 0)},
+		dmarket.ClosedTrade{Kind: dmarket.TradeSale, ID: "o2", Price: dmarket.NewMoney(500, dmarket.CurrencyUSD), ClosedAt: time.Unix(3000, 0)},
+		dmarket.ClosedTrade{Kind: dmarket.TradePurchase, ID: "t1", Price: dmarket.NewMoney(700, dmarket.CurrencyUSD), ClosedAt: time.Unix(1500, 0)},
+	)
+	router := gin.New()
+	router.Handle(mock.ClosedOffers().Endpoint())
+	router.Handle(mock.ClosedTargets().Endpoint())
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+	cases := []struct {
+		name           string
+		path           string
+		wantHTTPCode   int
+		wantBodyString string
+	}{
+		{name: "success: offers in range", path: "/marketplace-api/v1/user-offers/closed?GameID=a8db&ClosedFrom=1&ClosedTo=2000&Limit=10",
+			wantHTTPCode: http.StatusOK, wantBodyString: `"Fee":{"Currency":"USD","Amount":0.50},"OfferClosedAt":"1000"`},
+		{name: "success: offers page", path: "/marketplace-api/v1/user-offers/closed?GameID=a8db&ClosedFrom=1&ClosedTo=5000&Limit=1",
+			wantHTTPCode: http.StatusOK, wantBodyString: `"Cursor":"1"`},
+		{name: "success: targets", path: "/marketplace-api/v1/user-targets/closed?GameID=a8db&ClosedFrom=1&ClosedTo=5000&Limit=10",
+			wantHTTPCode: http.StatusOK, wantBodyString: `"TargetID":"t1"`},
+		{name: "error: reversed range", path: "/marketplace-api/v1/user-targets/closed?GameID=a8db&ClosedFrom=5000&ClosedTo=1&Limit=10",
+			wantHTTPCode: http.StatusBadRequest, wantBodyString: `"error":"BadRequest"`},
+		{name: "error: bad cursor", path: "/marketplace-api/v1/user-targets/closed?GameID=a8db&ClosedFrom=1&ClosedTo=5000&Limit=10&Cursor=x",
+			wantHTTPCode: http.StatusBadRequest, wantBodyString: `invalid cursor`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(ts.URL + tc.path)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantHTTPCode, resp.StatusCode)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Contains(t, string(body), tc.wantBodyString)
+		})
+	}
+}
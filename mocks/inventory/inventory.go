@@ -0,0 +1,120 @@
+package inventory
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransferParams struct {
+	Assets []dmarket.TransferAsset `json:"Assets" binding:"required,min=1,dive"`
+}
+
+type transfer struct {
+	dmarket.Transfer
+	created time.Time
+}
+
+/*
+Store is a mock of the inventory transfers which status advances over time:
+TransferStatusPending for the first step, then TransferStatusInProgress for the second step
+and TransferStatusSuccess, or TransferStatusFailed when the transfer has the failing asset
+*/
+type Store struct {
+	mu        sync.Mutex
+	step      time.Duration
+	failing   map[string]bool
+	transfers map[string]*transfer
+}
+
+// MustReturnSuccess creates a Store which transfers take two steps
+func MustReturnSuccess(step time.Duration) *Store {
+	return &Store{step: step, failing: make(map[string]bool), transfers: make(map[string]*transfer)}
+}
+
+// Fail makes the transfers of the assets fail
+func (s *Store) Fail(assetIDs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range assetIDs {
+		s.failing[id] = true
+	}
+}
+
+// Deposit handles POST /marketplace-api/v1/deposit-assets
+func (s *Store) Deposit() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodPost, "/marketplace-api/v1/deposit-assets", s.create(dmarket.TransferDeposit))
+}
+
+// Withdraw handles POST /marketplace-api/v1/withdraw-assets
+func (s *Store) Withdraw() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodPost, "/marketplace-api/v1/withdraw-assets", s.create(dmarket.TransferWithdraw))
+}
+
+// Status handles GET /marketplace-api/v1/transfer-status/{transferID}
+func (s *Store) Status() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodGet, "/marketplace-api/v1/transfer-status/:id", func(context *gin.Context) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		t, ok := s.transfers[context.Param("id")]
+		if !ok {
+			common.WriteError(context, http.StatusNotFound, "NotFound", fmt.Sprintf("transfer %q not found", context.Param("id")))
+			return
+		}
+		context.JSON(http.StatusOK, s.advance(t))
+	})
+}
+
+func (s *Store) create(kind dmarket.TransferKind) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		var params TransferParams
+		if err := context.ShouldBindJSON(&params); err != nil {
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			return
+		}
+		for _, asset := range params.Assets {
+			if asset.AssetID == "" {
+				common.WriteError(context, http.StatusBadRequest, "", "asset ID is required")
+				return
+			}
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		t := &transfer{
+			Transfer: dmarket.Transfer{
+				TransferID: string(kind) + "-" + strconv.Itoa(len(s.transfers)+1),
+				Kind:       kind,
+				Status:     dmarket.TransferStatusPending,
+				Assets:     params.Assets,
+			},
+			created: time.Now(),
+		}
+		s.transfers[t.TransferID] = t
+		context.JSON(http.StatusOK, t.Transfer)
+	}
+}
+
+// advance returns the transfer with the status of the elapsed steps
+func (s *Store) advance(t *transfer) dmarket.Transfer {
+	switch elapsed := time.Since(t.created); {
+	case elapsed < s.step:
+		t.Status = dmarket.TransferStatusPending
+	case elapsed < 2*s.step:
+		t.Status = dmarket.TransferStatusInProgress
+	default:
+		t.Status = dmarket.TransferStatusSuccess
+		for _, asset := range t.Assets {
+			if s.failing[asset.AssetID] {
+				t.Status, t.Error = dmarket.TransferStatusFailed, "asset "+asset.AssetID+" is not tradable"
+			}
+		}
+	}
+	return t.Transfer
+}
@@ -0,0 +1,70 @@
+package inventory_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/inventory"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+func TestStore(t *testing.T) {
+	mock := inventory.MustReturnSuccess(50 * time.Millisecond)
+	mock.Fail("broken")
+	router := gin.New()
+	router.Handle(mock.Deposit().Endpoint())
+	router.Handle(mock.Withdraw().Endpoint())
+	router.Handle(mock.Status().Endpoint())
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	create := func(path, body string) (int, dmarket.Transfer) {
+		resp, err := http.Post(ts.URL+path, "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		var transfer dmarket.Transfer
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&transfer))
+		return resp.StatusCode, transfer
+	}
+	status := func(id string) dmarket.Transfer {
+		resp, err := http.Get(ts.URL + "/marketplace-api/v1/transfer-status/" + id)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var transfer dmarket.Transfer
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&transfer))
+		return transfer
+	}
+
+	code, deposit := create("/marketplace-api/v1/deposit-assets", `{"Assets":[{"AssetID":"a1"}]}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, dmarket.TransferDeposit, deposit.Kind)
+	require.Equal(t, dmarket.TransferStatusPending, deposit.Status)
+	_, withdraw := create("/marketplace-api/v1/withdraw-assets", `{"Assets":[{"AssetID":"broken","LinkID":"l1"}]}`)
+	require.Equal(t, dmarket.TransferWithdraw, withdraw.Kind)
+
+	time.Sleep(60 * time.Millisecond)
+	require.Equal(t, dmarket.TransferStatusInProgress, status(deposit.TransferID).Status)
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, dmarket.TransferStatusSuccess, status(deposit.TransferID).Status)
+	require.Equal(t, dmarket.TransferStatusFailed, status(withdraw.TransferID).Status)
+
+	code, _ = create("/marketplace-api/v1/deposit-assets", `{"Assets":[]}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = create("/marketplace-api/v1/deposit-assets", `{"Assets":[{"LinkID":"l1"}]}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	resp, err := http.Get(ts.URL + "/marketplace-api/v1/transfer-status/unknown")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,161 @@
+package inventory
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/common"
+	"github.com/defernest/dmarket-go/mocks/items"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SyncParams struct {
+	Type   string `json:"Type" binding:"required,eq=Inventory"`
+	GameID string `json:"GameID" binding:"required,oneof=a8db 9a92 tf2 rust"`
+}
+
+type SyncStatusParams struct {
+	GameID string `form:"GameID" binding:"required,oneof=a8db 9a92 tf2 rust"`
+}
+
+/*
+Sync is a mock of the user inventory sync with Steam: the inventory has the before objects
+until the triggered sync is reported as SyncStatusInProgress by polls status requests,
+then the sync is SyncStatusDone with the later UpdatedAt and the inventory has the after objects.
+
+With Stale the triggered sync is reported by the state of the previous sync for the first status requests
+like the real API does right after the trigger.
+With WithoutUpdatedAt the trigger response has no UpdatedAt.
+*/
+type Sync struct {
+	mu        sync.Mutex
+	before    []dmarket.Object
+	after     []dmarket.Object
+	polls     int
+	stale     int
+	remaining int
+	staleLeft int
+	triggered bool
+	done      bool
+	failure   string
+	untimed   bool
+	last      dmarket.SyncState
+}
+
+// MustReturnSync creates a Sync which inventory flips from before to after when the sync is done
+func MustReturnSync(before, after []dmarket.Object, polls int) *Sync {
+	return &Sync{
+		before: before,
+		after:  after,
+		polls:  polls,
+		last:   dmarket.SyncState{Status: dmarket.SyncStatusDone, UpdatedAt: time.Now().Add(-time.Hour).Unix()},
+	}
+}
+
+// Fail makes the triggered sync finish with SyncStatusFailed and the reason, the inventory is not changed then
+func (s *Sync) Fail(reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failure = reason
+}
+
+// Stale makes the trigger and the first polls status requests of the triggered sync report the previous sync
+func (s *Sync) Stale(polls int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stale = polls
+}
+
+// WithoutUpdatedAt makes the trigger respond with the state without UpdatedAt
+func (s *Sync) WithoutUpdatedAt() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.untimed = true
+}
+
+// Trigger handles POST /marketplace-api/v1/user-inventory/sync
+func (s *Sync) Trigger() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodPost, "/marketplace-api/v1/user-inventory/sync", func(context *gin.Context) {
+		var params SyncParams
+		if err := context.ShouldBindJSON(&params); err != nil {
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.triggered, s.remaining, s.staleLeft = true, s.polls, s.stale
+		state := s.state(dmarket.Game(params.GameID))
+		if s.untimed {
+			context.JSON(http.StatusOK, gin.H{"GameID": state.GameID, "Status": state.Status})
+			return
+		}
+		context.JSON(http.StatusOK, state)
+	})
+}
+
+// Status handles GET /marketplace-api/v1/user-inventory/sync-status?GameID={game}
+func (s *Sync) Status() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodGet, "/marketplace-api/v1/user-inventory/sync-status", func(context *gin.Context) {
+		var params SyncStatusParams
+		if err := context.ShouldBindQuery(&params); err != nil {
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		game := dmarket.Game(params.GameID)
+		switch {
+		case !s.triggered:
+		case s.staleLeft > 0:
+			state := s.state(game)
+			s.staleLeft--
+			context.JSON(http.StatusOK, state)
+			return
+		case s.remaining > 0:
+			s.remaining--
+		default:
+			s.finish()
+		}
+		context.JSON(http.StatusOK, s.state(game))
+	})
+}
+
+// finish finishes the triggered sync, UpdatedAt always moves past the previous sync
+func (s *Sync) finish() {
+	updatedAt := time.Now().Unix()
+	if updatedAt <= s.last.UpdatedAt {
+		updatedAt = s.last.UpdatedAt + 1
+	}
+	s.triggered = false
+	s.last = dmarket.SyncState{Status: dmarket.SyncStatusDone, UpdatedAt: updatedAt}
+	if s.failure != "" {
+		s.last.Status, s.last.Error = dmarket.SyncStatusFailed, s.failure
+		return
+	}
+	s.done = true
+}
+
+// Items handles GET /exchange/v1/user/items with the inventory before or after the sync
+func (s *Sync) Items() *common.EndpointBehavior {
+	return items.MustReturnInventory(func() []dmarket.Object {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.done {
+			return s.after
+		}
+		return s.before
+	})
+}
+
+// state returns the state of the triggered sync, the previous sync is reported while it is stale
+func (s *Sync) state(game dmarket.Game) dmarket.SyncState {
+	state := s.last
+	state.GameID = game
+	if s.triggered && s.staleLeft == 0 {
+		state.Status, state.Error = dmarket.SyncStatusInProgress, ""
+	}
+	return state
+}
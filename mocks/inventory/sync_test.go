@@ -0,0 +1,99 @@
+package inventory_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/inventory"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSync(t *testing.T) {
+	before := []dmarket.Object{{ItemID: "old", Title: "old", Price: dmarket.Price{Usd: 10}}}
+	after := []dmarket.Object{{ItemID: "new", Title: "new", Price: dmarket.Price{Usd: 20}}}
+	mock := inventory.MustReturnSync(before, after, 1)
+	router := gin.New()
+	router.Handle(mock.Trigger().Endpoint())
+	router.Handle(mock.Status().Endpoint())
+	router.Handle(mock.Items().Endpoint())
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	status := func() dmarket.SyncState {
+		resp, err := http.Get(ts.URL + "/marketplace-api/v1/user-inventory/sync-status?GameID=a8db")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var state dmarket.SyncState
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&state))
+		return state
+	}
+	inventoryItems := func() []dmarket.Object {
+		resp, err := http.Get(ts.URL + "/exchange/v1/user/items?gameId=a8db&currency=USD&limit=100")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var page dmarket.GetItemsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+		return page.Objects
+	}
+
+	initial := status()
+	require.Equal(t, dmarket.SyncStatusDone, initial.Status)
+	require.NotZero(t, initial.UpdatedAt)
+	require.Equal(t, before, inventoryItems())
+
+	resp, err := http.Post(ts.URL+"/marketplace-api/v1/user-inventory/sync", "application/json", strings.NewReader(`{"Type":"Inventory"}`))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, err = http.Post(ts.URL+"/marketplace-api/v1/user-inventory/sync", "application/json", strings.NewReader(`{"Type":"Inventory","GameID":"a8db"}`))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.Equal(t, dmarket.SyncStatusInProgress, status().Status)
+	require.Equal(t, before, inventoryItems())
+	done := status()
+	require.Equal(t, dmarket.SyncStatusDone, done.Status)
+	require.Greater(t, done.UpdatedAt, initial.UpdatedAt)
+	require.Equal(t, after, inventoryItems())
+
+	mock.Fail("private inventory")
+	_, err = http.Post(ts.URL+"/marketplace-api/v1/user-inventory/sync", "application/json", strings.NewReader(`{"Type":"Inventory","GameID":"a8db"}`))
+	require.NoError(t, err)
+	status()
+	failed := status()
+	require.Equal(t, dmarket.SyncStatusFailed, failed.Status)
+	require.Equal(t, "private inventory", failed.Error)
+
+	mock.Stale(2)
+	trigger := func() dmarket.SyncState {
+		resp, err := http.Post(ts.URL+"/marketplace-api/v1/user-inventory/sync", "application/json", strings.NewReader(`{"Type":"Inventory","GameID":"a8db"}`))
+		require.NoError(t, err)
+		var state dmarket.SyncState
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&state))
+		return state
+	}
+	require.Equal(t, failed, trigger())
+	require.Equal(t, failed, status())
+	require.Equal(t, failed, status())
+	require.Equal(t, dmarket.SyncStatusInProgress, status().Status)
+	refailed := status()
+	require.Equal(t, dmarket.SyncStatusFailed, refailed.Status)
+	require.Greater(t, refailed.UpdatedAt, failed.UpdatedAt)
+
+	mock.WithoutUpdatedAt()
+	resp, err = http.Post(ts.URL+"/marketplace-api/v1/user-inventory/sync", "application/json", strings.NewReader(`{"Type":"Inventory","GameID":"a8db"}`))
+	require.NoError(t, err)
+	var untimed map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&untimed))
+	require.NotContains(t, untimed, "UpdatedAt")
+	require.Equal(t, string(dmarket.SyncStatusFailed), untimed["Status"])
+
+	resp, err = http.Get(ts.URL + "/marketplace-api/v1/user-inventory/sync-status")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
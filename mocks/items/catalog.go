@@ -0,0 +1,154 @@
+package items
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+Catalog returns count market items with the unique ItemID and the USD prices from 1 to maxPrice, sorted by the price.
+The catalog is the same for the same arguments, the most items are cheap like on the real market.
+*/
+func Catalog(count int, maxPrice dmarket.Cents) []dmarket.Object {
+	r := rand.New(rand.NewSource(int64(count)))
+	catalog := make([]dmarket.Object, count)
+	for i := range catalog {
+		u := r.Float64()
+		catalog[i] = dmarket.Object{
+			ItemID: fmt.Sprintf("item-%d", i),
+			Title:  fmt.Sprintf("item %d", i),
+			GameID: string(dmarket.DefaultGame),
+			Type:   string(dmarket.OfferTypeDmarket),
+			Price:  dmarket.Price{Usd: 1 + dmarket.Cents(u*u*u*float64(maxPrice-1))},
+		}
+		catalog[i].Extra.OfferID = "offer-" + strconv.Itoa(i)
+	}
+	sort.SliceStable(catalog, func(i, j int) bool { return catalog[i].Price.Usd < catalog[j].Price.Usd })
+	return catalog
+}
+
+/*
+MustReturnCatalog handles GET /exchange/v1/market/items with the catalog items in the price range and with the title
+(exactly the title with exact=true) in the order of the catalog, the cursor is an offset of the next page and the total is the count of the matched items.
+The price range is inclusive and not applied when both priceFrom and priceTo are zero.
+*/
+func MustReturnCatalog(catalog []dmarket.Object) *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodGet, "/exchange/v1/market/items", func(context *gin.Context) {
+		catalogPage(context, func(string) []dmarket.Object { return catalog })
+	})
+}
+
+/*
+MustReturnSnapshots handles GET /exchange/v1/market/items like MustReturnCatalog,
+but every scan started with the empty cursor gets the next snapshot, the last snapshot is kept then.
+Use Evolve to make the next snapshot of the catalog.
+*/
+func MustReturnSnapshots(snapshots ...[]dmarket.Object) *common.EndpointBehavior {
+	var (
+		mu   sync.Mutex
+		next int
+	)
+	return common.NewEndpointBehavior(http.MethodGet, "/exchange/v1/market/items", func(context *gin.Context) {
+		catalogPage(context, func(cursor string) []dmarket.Object {
+			mu.Lock()
+			defer mu.Unlock()
+			if cursor == "" && next < len(snapshots) {
+				next++
+			}
+			if next == 0 {
+				return nil
+			}
+			return snapshots[next-1]
+		})
+	})
+}
+
+/*
+MustReturnInventory handles GET /exchange/v1/user/items like MustReturnCatalog with the current user inventory,
+the inventory is got once per scan started with the empty cursor
+*/
+func MustReturnInventory(inventory func() []dmarket.Object) *common.EndpointBehavior {
+	var (
+		mu      sync.Mutex
+		current []dmarket.Object
+	)
+	return common.NewEndpointBehavior(http.MethodGet, "/exchange/v1/user/items", func(context *gin.Context) {
+		catalogPage(context, func(cursor string) []dmarket.Object {
+			mu.Lock()
+			defer mu.Unlock()
+			if cursor == "" {
+				current = inventory()
+			}
+			return current
+		})
+	})
+}
+
+/*
+Evolve returns the next snapshot of the catalog: the first removed items are sold,
+the last repriced items get the price cut by 10% and added new items are listed
+*/
+func Evolve(catalog []dmarket.Object, removed, repriced, added int) []dmarket.Object {
+	next := make([]dmarket.Object, 0, len(catalog)-removed+added)
+	next = append(next, catalog[removed:]...)
+	for i := len(next) - 1; i >= 0 && i >= len(next)-repriced; i-- {
+		next[i].Price.Usd -= next[i].Price.Usd / 10
+	}
+	for i, object := range Catalog(added, 100000) {
+		object.ItemID = fmt.Sprintf("%s-%d-%d", object.ItemID, len(catalog), i)
+		next = append(next, object)
+	}
+	sort.SliceStable(next, func(i, j int) bool { return next[i].Price.Usd < next[j].Price.Usd })
+	return next
+}
+
+// catalogPage writes the page of the catalog returned for the cursor
+func catalogPage(context *gin.Context, catalog func(cursor string) []dmarket.Object) {
+	var params Params
+	if err := context.ShouldBindQuery(&params); err != nil {
+		common.WriteError(context, http.StatusBadRequest, "", err.Error())
+		return
+	}
+	offset, err := strconv.Atoi(params.Cursor)
+	if params.Cursor == "" {
+		offset, err = 0, nil
+	}
+	if err != nil || offset < 0 {
+		common.WriteError(context, http.StatusBadRequest, "", fmt.Sprintf("invalid cursor %q", params.Cursor))
+		return
+	}
+
+	var matched []dmarket.Object
+	for _, object := range catalog(params.Cursor) {
+		price := int(object.Price.Usd)
+		if (params.PriceFrom != 0 || params.PriceTo != 0) && (price < params.PriceFrom || price > params.PriceTo) {
+			continue
+		}
+		if !strings.Contains(object.Title, params.Title) || params.Exact && object.Title != params.Title {
+			continue
+		}
+		matched = append(matched, object)
+	}
+
+	resp := dmarket.GetItemsResponse{Objects: []dmarket.Object{}, Total: dmarket.Total{Items: len(matched)}}
+	if offset < len(matched) {
+		end := offset + params.Limit
+		if end < len(matched) {
+			resp.Cursor = strconv.Itoa(end)
+		} else {
+			end = len(matched)
+		}
+		resp.Objects = matched[offset:end]
+	}
+	context.JSON(http.StatusOK, &resp)
+}
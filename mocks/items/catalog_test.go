@@ -0,0 +1,82 @@
+package items_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/items"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalog(t *testing.T) {
+	catalog := items.Catalog(1000, 10000)
+	require.Len(t, catalog, 1000)
+	require.Equal(t, catalog, items.Catalog(1000, 10000))
+	ids := make(map[string]bool)
+	for i, object := range catalog {
+		require.False(t, ids[object.ItemID])
+		ids[object.ItemID] = true
+		require.GreaterOrEqual(t, object.Price.Usd, dmarket.Cents(1))
+		require.LessOrEqual(t, object.Price.Usd, dmarket.Cents(10000))
+		if i > 0 {
+			require.LessOrEqual(t, catalog[i-1].Price.Usd, object.Price.Usd)
+		}
+	}
+}
+
+func TestMustReturnCatalog(t *testing.T) {
+	catalog := items.Catalog(1000, 10000)
+	router := gin.New()
+	router.Handle(items.MustReturnCatalog(catalog).Endpoint())
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	query := url.Values{"gameId": {"9a92"}, "currency": {"USD"}, "limit": {"100"}, "priceFrom": {"100"}, "priceTo": {"500"}}
+	var want int
+	for _, object := range catalog {
+		if object.Price.Usd >= 100 && object.Price.Usd <= 500 {
+			want++
+		}
+	}
+	objects := getAllItems(t, ts.URL, query)
+	require.Len(t, objects, want)
+	for _, object := range objects {
+		require.GreaterOrEqual(t, object.Price.Usd, dmarket.Cents(100))
+		require.LessOrEqual(t, object.Price.Usd, dmarket.Cents(500))
+	}
+
+	title := url.Values{"gameId": {"9a92"}, "currency": {"USD"}, "limit": {"100"}, "title": {"item 1"}}
+	require.Len(t, getAllItems(t, ts.URL, title), 111)
+	title.Set("exact", "true")
+	exact := getAllItems(t, ts.URL, title)
+	require.Len(t, exact, 1)
+	require.Equal(t, "item 1", exact[0].Title)
+
+	resp, err := http.Get(ts.URL + "/exchange/v1/market/items?gameId=9a92&currency=USD&limit=100&cursor=x")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestMustReturnSnapshots(t *testing.T) {
+	first := items.Catalog(100, 100000)
+	second := items.Evolve(first, 10, 5, 20)
+	require.Len(t, second, 110)
+	require.Len(t, first, 100)
+
+	router := gin.New()
+	router.Handle(items.MustReturnSnapshots(first, second).Endpoint())
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	query := url.Values{"gameId": {"9a92"}, "currency": {"USD"}, "limit": {"30"}}
+	require.Equal(t, first, getAllItems(t, ts.URL, query))
+	query.Del("cursor")
+	require.Equal(t, second, getAllItems(t, ts.URL, query))
+	query.Del("cursor")
+	require.Equal(t, second, getAllItems(t, ts.URL, query), "the last snapshot is kept")
+}
@@ -0,0 +1,100 @@
+package items
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/defernest/dmarket-go/dmarket"
+)
+
+var treeFilterKeys = map[string]bool{
+	"exterior":       true,
+	"categoryPath":   true,
+	"rarity":         true,
+	"phase":          true,
+	"floatValueFrom": true,
+	"floatValueTo":   true,
+}
+
+/*
+Filters parses and checks the treeFilters and types of the query
+
+	treeFilters=exterior[]=factory new,categoryPath[]=knife
+	types=dmarket,p2p
+*/
+func (q Params) Filters() (map[string][]string, error) {
+	filters := make(map[string][]string)
+	if q.TreeFilters != "" {
+		for _, filter := range strings.Split(q.TreeFilters, ",") {
+			kv := strings.SplitN(filter, "[]=", 2)
+			if len(kv) != 2 || !treeFilterKeys[kv[0]] || kv[1] == "" {
+				return nil, fmt.Errorf("invalid tree filter %q", filter)
+			}
+			filters[kv[0]] = append(filters[kv[0]], kv[1])
+		}
+		for _, key := range []string{"floatValueFrom", "floatValueTo"} {
+			for _, value := range filters[key] {
+				if f, err := strconv.ParseFloat(value, 64); err != nil || f < 0 || f > 1 {
+					return nil, fmt.Errorf("invalid tree filter %s %q", key, value)
+				}
+			}
+		}
+	}
+	if q.Types != "" {
+		for _, t := range strings.Split(q.Types, ",") {
+			if dmarket.OfferType(t) != dmarket.OfferTypeDmarket && dmarket.OfferType(t) != dmarket.OfferTypeP2P {
+				return nil, fmt.Errorf("invalid offer type %q", t)
+			}
+			filters["types"] = append(filters["types"], t)
+		}
+	}
+	if q.Exact && q.Title == "" {
+		return nil, fmt.Errorf("exact search without title")
+	}
+	return filters, nil
+}
+
+// applyFilters makes the generated items match the filters of the query and sorts them
+func (q Params) applyFilters(items []dmarket.Object) {
+	filters, err := q.Filters()
+	if err != nil {
+		panic(err)
+	}
+	pick := func(values []string, field *string) {
+		if len(values) > 0 {
+			*field = values[rand.Intn(len(values))]
+		}
+	}
+	types := filters["types"]
+	if len(types) == 0 {
+		// the listed offers are always of the known offer types
+		types = []string{string(dmarket.OfferTypeDmarket), string(dmarket.OfferTypeP2P)}
+	}
+	for i := range items {
+		pick(filters["exterior"], &items[i].Extra.Exterior)
+		pick(filters["categoryPath"], &items[i].Extra.CategoryPath)
+		pick(filters["rarity"], &items[i].Extra.Rarity)
+		pick(types, &items[i].Type)
+	}
+	var less func(a, b dmarket.Object) bool
+	switch q.OrderBy {
+	case "title":
+		less = func(a, b dmarket.Object) bool { return a.Title < b.Title }
+	case "price":
+		currency := dmarket.Currency(q.Currency)
+		less = func(a, b dmarket.Object) bool { return a.Price.In(currency).Amount < b.Price.In(currency).Amount }
+	case "discount":
+		less = func(a, b dmarket.Object) bool { return a.Discount < b.Discount }
+	default:
+		return
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if q.OrderDir == "desc" {
+			return less(items[j], items[i])
+		}
+		return less(items[i], items[j])
+	})
+}
@@ -1,56 +1,79 @@
 package items
 
 import (
+	"fmt"
 	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/common"
 	"net/http"
+	"sync"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Params struct {
-	GameId    string `form:"gameId" binding:"required"`
+	GameId    string `form:"gameId" binding:"required,oneof=a8db 9a92 tf2 rust"`
 	Title     string `form:"title"`
-	Currency  string `form:"currency" binding:"required,contains=USD"`
+	Currency  string `form:"currency" binding:"required,oneof=USD DMC"`
 	Cursor    string `form:"cursor"`
 	Limit     int    `form:"limit" binding:"required,gte=0,lte=100"`
 	PriceFrom int    `form:"priceFrom" binding:"gte=0"`
 	PriceTo   int    `form:"priceTo" binding:"gtefield=PriceFrom"`
+	OrderBy   string `form:"orderBy" binding:"omitempty,oneof=title price discount updated best_discount best_deal"`
+	OrderDir  string `form:"orderDir" binding:"required_with=OrderBy,omitempty,oneof=asc desc"`
+	// TreeFilters is validated by Params.Filters
+	TreeFilters string `form:"treeFilters"`
+	Types       string `form:"types"`
+	Exact       bool   `form:"exact"`
 }
 
+/*
+EndpointBehaviorOK returns count items for every scan, the scan starts with the empty cursor.
+The rest items of the scans are kept by the issued cursors, so the scans can run concurrently.
+The last page of the scan has the empty cursor.
+*/
 type EndpointBehaviorOK struct {
-	count  int
-	cursor string
+	count int
+	mu    sync.Mutex
+	scans map[string]int
 }
 
 func (e *EndpointBehaviorOK) Endpoint() (httpMethod string, relativePath string, handler gin.HandlerFunc) {
 	return http.MethodGet, "/exchange/v1/market/items", func(context *gin.Context) {
 		defer func() {
 			if r := recover(); r != nil {
-				context.String(dmarket.ErrorRepresentation{Response: dmarket.Response{StatusCode: http.StatusInternalServerError}}.String())
+				common.WriteError(context, http.StatusInternalServerError, "", fmt.Sprint(r))
 			}
 		}()
 
 		var itemsQuery Params
 		err := context.ShouldBindQuery(&itemsQuery)
-		if err != nil || !e.cursorValid(itemsQuery.Cursor) {
-			context.String(dmarket.ErrorRepresentation{Response: dmarket.Response{StatusCode: http.StatusBadRequest}}.String())
+		if err != nil {
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			return
+		}
+		if _, err = itemsQuery.Filters(); err != nil {
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			return
+		}
+		rest, ok := e.rest(itemsQuery.Cursor)
+		if !ok {
+			common.WriteError(context, http.StatusBadRequest, "", fmt.Sprintf("invalid cursor %q", itemsQuery.Cursor))
 			return
 		}
 
-		resp := dmarket.GetItemsResponse{Total: dmarket.Total{Items: e.count}, Cursor: e.cursor}
-		if (e.count - itemsQuery.Limit) >= 0 {
-			resp.Objects = itemsQuery.GenerateItems(itemsQuery.Limit)
-		} else {
-			resp.Objects = itemsQuery.GenerateItems(e.count)
+		count := itemsQuery.Limit
+		if rest < count {
+			count = rest
 		}
-		context.JSON(http.StatusOK, &resp)
-		e.count -= itemsQuery.Limit
-		if e.count < 0 {
-			e.count = 0
+		resp := dmarket.GetItemsResponse{Total: dmarket.Total{Items: rest}}
+		if rest > count {
+			resp.Cursor = e.issue(rest - count)
 		}
+		resp.Objects = itemsQuery.GenerateItems(count)
+		context.JSON(http.StatusOK, &resp)
 	}
 }
 
 func MustReturnSuccess(count int) *EndpointBehaviorOK {
-	return &EndpointBehaviorOK{count: count}
+	return &EndpointBehaviorOK{count: count, scans: make(map[string]int)}
 }
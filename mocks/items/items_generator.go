@@ -6,7 +6,6 @@ import (
 	"github.com/defernest/dmarket-go/dmarket"
 	"math/rand"
 	"reflect"
-	"strconv"
 )
 
 func init() {
@@ -16,12 +15,28 @@ func init() {
 	}
 }
 
-func (e *EndpointBehaviorOK) cursorValid(queryCursor string) bool {
-	if queryCursor == e.cursor {
-		e.cursor = faker.Password()
-		return true
+// rest returns the rest items of the scan and forgets the cursor, the empty cursor starts the new scan
+func (e *EndpointBehaviorOK) rest(cursor string) (int, bool) {
+	if cursor == "" {
+		return e.count, true
 	}
-	return false
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	rest, ok := e.scans[cursor]
+	delete(e.scans, cursor)
+	return rest, ok
+}
+
+// issue returns the new cursor of the scan with the rest items
+func (e *EndpointBehaviorOK) issue(rest int) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	cursor := faker.Password()
+	for _, ok := e.scans[cursor]; ok || cursor == ""; _, ok = e.scans[cursor] {
+		cursor = faker.Password()
+	}
+	e.scans[cursor] = rest
+	return cursor
 }
 
 func (q Params) GenerateItems(count int) []dmarket.Object {
@@ -35,17 +50,43 @@ func (q Params) GenerateItems(count int) []dmarket.Object {
 			items[i].Title = q.Title
 			items[i].Extra.Name = q.Title
 		}
-		if q.PriceFrom == 0 && q.PriceTo == 0 {
-			items[i].Price.Usd = strconv.Itoa(rand.Intn(10000000) + 1)
+		price := dmarket.Cents(rand.Intn(10000000) + 1)
+		if q.PriceFrom != 0 || q.PriceTo != 0 {
+			price = dmarket.Cents(rand.Intn(q.PriceTo-q.PriceFrom+1) + q.PriceFrom)
+		}
+		// the price range filters the prices in the requested currency
+		if dmarket.Currency(q.Currency) == dmarket.CurrencyDMC {
+			items[i].Price.Dmc = price
 		} else {
-			items[i].Price.Usd = strconv.Itoa(rand.Intn(q.PriceTo-q.PriceFrom+1) + q.PriceFrom)
+			items[i].Price.Usd = price
 		}
 		items[i].GameID = q.GameId
 		items[i].Extra.GameID = q.GameId
+		gameExtra(dmarket.Game(q.GameId), &items[i].Extra)
 	}
+	q.applyFilters(items)
 	return items
 }
 
+// gameExtra clears the Extra fields that the game does not have, see dmarket.Extra
+func gameExtra(game dmarket.Game, extra *dmarket.Extra) {
+	switch game {
+	case dmarket.GameCSGO:
+		extra.Hero, extra.Gems, extra.Growth = "", nil, 0
+		extra.NameColor, extra.BackgroundColor, extra.SerialNumber = "", "", 0
+	case dmarket.GameDota2:
+		extra.Exterior, extra.FloatValue, extra.Stickers = "", 0, nil
+		extra.InspectInGame, extra.SerialNumber = "", 0
+	case dmarket.GameTF2:
+		extra.Hero, extra.Gems, extra.Growth = "", nil, 0
+		extra.Exterior, extra.FloatValue, extra.Stickers, extra.SerialNumber = "", 0, nil, 0
+	case dmarket.GameRust:
+		extra.Hero, extra.Gems, extra.Growth = "", nil, 0
+		extra.Exterior, extra.FloatValue, extra.Stickers, extra.InspectInGame = "", 0, nil, ""
+		extra.NameColor, extra.BackgroundColor = "", ""
+	}
+}
+
 func providers() error {
 	err := faker.AddProvider("classID", func(v reflect.Value) (interface{}, error) {
 		cID := rand.Intn(9999999999)
@@ -56,8 +97,7 @@ func providers() error {
 		return err
 	}
 	err = faker.AddProvider("dprice", func(v reflect.Value) (interface{}, error) {
-		price := strconv.Itoa(rand.Intn(99999))
-		return price, nil
+		return dmarket.Cents(rand.Intn(99999) + 1), nil
 	})
 	if err != nil {
 		return err
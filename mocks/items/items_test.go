@@ -34,10 +34,10 @@ func getAllItems(t *testing.T, tsURL string, query url.Values) []dmarket.Object
 		err = json.Unmarshal(body, &ims)
 		require.NoError(t, err)
 		query.Set("cursor", ims.Cursor)
-		if len(ims.Objects) == 0 {
+		objects = append(objects, ims.Objects...)
+		if ims.Cursor == "" {
 			break
 		}
-		objects = append(objects, ims.Objects...)
 	}
 	return objects
 }
@@ -98,6 +98,119 @@ func TestMustReturnSuccess(t *testing.T) {
 			wantHTTPCode:     http.StatusBadRequest,
 			wantObjectsCount: 0,
 		},
+		{
+			name: "success: filters",
+			query: map[string][]string{
+				"gameId":      {"a8db"},
+				"currency":    {"USD"},
+				"limit":       {"100"},
+				"orderBy":     {"price"},
+				"orderDir":    {"desc"},
+				"treeFilters": {"exterior[]=factory new,categoryPath[]=knife,floatValueFrom[]=0,floatValueTo[]=0.07"},
+				"types":       {"dmarket,p2p"},
+				"title":       {"title"},
+				"exact":       {"true"},
+			},
+			wantHTTPCode:     http.StatusOK,
+			wantObjectsCount: 10,
+		},
+		{
+			name: "error: unknown orderBy",
+			query: map[string][]string{
+				"gameId":   {"a8db"},
+				"currency": {"USD"},
+				"limit":    {"100"},
+				"orderBy":  {"popularity"},
+				"orderDir": {"asc"},
+			},
+			wantHTTPCode:     http.StatusBadRequest,
+			wantObjectsCount: 0,
+		},
+		{
+			name: "error: orderBy without orderDir",
+			query: map[string][]string{
+				"gameId":   {"a8db"},
+				"currency": {"USD"},
+				"limit":    {"100"},
+				"orderBy":  {"price"},
+			},
+			wantHTTPCode:     http.StatusBadRequest,
+			wantObjectsCount: 0,
+		},
+		{
+			name: "error: unknown tree filter",
+			query: map[string][]string{
+				"gameId":      {"a8db"},
+				"currency":    {"USD"},
+				"limit":       {"100"},
+				"treeFilters": {"color[]=red"},
+			},
+			wantHTTPCode:     http.StatusBadRequest,
+			wantObjectsCount: 0,
+		},
+		{
+			name: "error: float out of range",
+			query: map[string][]string{
+				"gameId":      {"a8db"},
+				"currency":    {"USD"},
+				"limit":       {"100"},
+				"treeFilters": {"floatValueTo[]=2"},
+			},
+			wantHTTPCode:     http.StatusBadRequest,
+			wantObjectsCount: 0,
+		},
+		{
+			name: "error: unknown type",
+			query: map[string][]string{
+				"gameId":   {"a8db"},
+				"currency": {"USD"},
+				"limit":    {"100"},
+				"types":    {"auction"},
+			},
+			wantHTTPCode:     http.StatusBadRequest,
+			wantObjectsCount: 0,
+		},
+		{
+			name: "error: exact without title",
+			query: map[string][]string{
+				"gameId":   {"a8db"},
+				"currency": {"USD"},
+				"limit":    {"100"},
+				"exact":    {"true"},
+			},
+			wantHTTPCode:     http.StatusBadRequest,
+			wantObjectsCount: 0,
+		},
+		{
+			name: "success: DMC currency",
+			query: map[string][]string{
+				"gameId":   {"9a92"},
+				"currency": {"DMC"},
+				"limit":    {"100"},
+			},
+			wantHTTPCode:     http.StatusOK,
+			wantObjectsCount: 10,
+		},
+		{
+			name: "error: unknown gameId",
+			query: map[string][]string{
+				"gameId":   {"9b92"},
+				"currency": {"USD"},
+				"limit":    {"100"},
+			},
+			wantHTTPCode:     http.StatusBadRequest,
+			wantObjectsCount: 0,
+		},
+		{
+			name: "success: csgo gameId",
+			query: map[string][]string{
+				"gameId":   {"a8db"},
+				"currency": {"USD"},
+				"limit":    {"100"},
+			},
+			wantHTTPCode:     http.StatusOK,
+			wantObjectsCount: 10,
+		},
 		{
 			name: "error: no currency",
 			query: map[string][]string{
@@ -197,7 +310,7 @@ func TestMustReturnSuccess(t *testing.T) {
 				require.Equal(t, tc.wantHTTPCode, resp.StatusCode)
 				body, err := io.ReadAll(resp.Body)
 				require.NoError(t, err)
-				require.Equal(t, "400: Bad Request", string(body))
+				require.Contains(t, string(body), `"error":"BadRequest"`)
 			}
 			ts.Close()
 		})
@@ -216,15 +329,16 @@ func TestMustReturnSuccess(t *testing.T) {
 			"priceFrom": {strconv.Itoa(priceFrom)},
 			"priceTo":   {strconv.Itoa(priceTo)},
 		}
-		for i := 0; i < 1000; i++ {
+		// every scan generates all items again, a few scans are enough to check the random values
+		for i := 0; i < 10; i++ {
 			objects := getAllItems(t, ts.URL, query)
+			require.Len(t, objects, 100)
 			for _, object := range objects {
 				require.Equal(t, query.Get("gameId"), object.GameID)
 				require.Equal(t, query.Get("gameId"), object.Extra.GameID)
 				require.Equal(t, query.Get("title"), object.Title)
 				require.Equal(t, query.Get("title"), object.Extra.Name)
-				itemPrice, err := strconv.Atoi(object.Price.Usd)
-				require.NoError(t, err)
+				itemPrice := int(object.Price.Usd)
 				require.GreaterOrEqual(t, itemPrice, priceFrom)
 				require.LessOrEqual(t, itemPrice, priceTo)
 			}
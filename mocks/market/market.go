@@ -0,0 +1,106 @@
+package market
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AggregatedPricesParams struct {
+	GameID string   `form:"gameId" binding:"required"`
+	Titles []string `form:"Titles" binding:"required,max=100"`
+	Limit  int      `form:"Limit" binding:"omitempty,gte=1,lte=100"`
+}
+
+type LastSalesParams struct {
+	GameID string `form:"gameId" binding:"required"`
+	Title  string `form:"title" binding:"required"`
+	Limit  int    `form:"limit" binding:"required,gte=1,lte=500"`
+}
+
+// Market is a mock price-aggregator and trade-aggregator which prices and sales can be changed between requests
+type Market struct {
+	mu     sync.Mutex
+	prices map[string]dmarket.AggregatedPrice
+	sales  map[string][]dmarket.Sale
+}
+
+// MustReturnSuccess creates a Market with the aggregated prices of the titles
+func MustReturnSuccess(prices ...dmarket.AggregatedPrice) *Market {
+	m := &Market{prices: make(map[string]dmarket.AggregatedPrice), sales: make(map[string][]dmarket.Sale)}
+	m.SetPrices(prices...)
+	return m
+}
+
+// SetPrices adds or replaces the aggregated prices of the titles
+func (m *Market) SetPrices(prices ...dmarket.AggregatedPrice) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, price := range prices {
+		m.prices[price.Title] = price
+	}
+}
+
+// SetSales replaces the last sales of the title, the newest first
+func (m *Market) SetSales(title string, sales ...dmarket.Sale) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sales[title] = sales
+}
+
+// AggregatedPrices handles GET /price-aggregator/v1/aggregated-prices, the unknown titles are skipped
+func (m *Market) AggregatedPrices() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodGet, "/price-aggregator/v1/aggregated-prices", func(context *gin.Context) {
+		var params AggregatedPricesParams
+		if err := context.ShouldBindQuery(&params); err != nil {
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			return
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		titles := make([]gin.H, 0, len(params.Titles))
+		for _, title := range params.Titles {
+			price, ok := m.prices[title]
+			if !ok {
+				continue
+			}
+			titles = append(titles, gin.H{
+				"MarketHashName": price.Title,
+				"Offers":         gin.H{"BestPrice": price.BestAsk.String(), "Count": price.AskCount},
+				"Orders":         gin.H{"BestPrice": price.BestBid.String(), "Count": price.BidCount},
+			})
+		}
+		context.JSON(http.StatusOK, gin.H{"AggregatedTitles": titles, "Total": strconv.Itoa(len(titles))})
+	})
+}
+
+// LastSales handles GET /trade-aggregator/v1/last-sales, the title without sales has an empty list
+func (m *Market) LastSales() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodGet, "/trade-aggregator/v1/last-sales", func(context *gin.Context) {
+		var params LastSalesParams
+		if err := context.ShouldBindQuery(&params); err != nil {
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			return
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		sales := m.sales[params.Title]
+		if len(sales) > params.Limit {
+			sales = sales[:params.Limit]
+		}
+		list := make([]gin.H, 0, len(sales))
+		for _, sale := range sales {
+			list = append(list, gin.H{
+				"price":           sale.Price.String(),
+				"date":            strconv.FormatInt(sale.Date.Unix(), 10),
+				"txOperationType": sale.Type,
+			})
+		}
+		context.JSON(http.StatusOK, gin.H{"sales": list})
+	})
+}
@@ -0,0 +1,58 @@
+package market_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/market"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+func TestMarket(t *testing.T) {
+	mock := market.MustReturnSuccess(dmarket.AggregatedPrice{Title: "a", BestAsk: 123, AskCount: 2, BestBid: 100, BidCount: 1})
+	mock.SetSales("a", dmarket.Sale{Price: 110, Date: time.Unix(1600000000, 0), Type: dmarket.SaleOffer})
+	router := gin.New()
+	router.Handle(mock.AggregatedPrices().Endpoint())
+	router.Handle(mock.LastSales().Endpoint())
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+	cases := []struct {
+		name           string
+		path           string
+		wantHTTPCode   int
+		wantBodyString string
+	}{
+		{name: "success: prices", path: "/price-aggregator/v1/aggregated-prices?gameId=a8db&Titles=a&Titles=b",
+			wantHTTPCode: http.StatusOK, wantBodyString: `"Offers":{"BestPrice":"1.23","Count":2}`},
+		{name: "error: no titles", path: "/price-aggregator/v1/aggregated-prices?gameId=a8db",
+			wantHTTPCode: http.StatusBadRequest, wantBodyString: `"error":"BadRequest"`},
+		{name: "success: sales", path: "/trade-aggregator/v1/last-sales?gameId=a8db&title=a&limit=10",
+			wantHTTPCode: http.StatusOK, wantBodyString: `{"date":"1600000000","price":"1.10","txOperationType":"Offer"}`},
+		{name: "success: no sales", path: "/trade-aggregator/v1/last-sales?gameId=a8db&title=b&limit=10",
+			wantHTTPCode: http.StatusOK, wantBodyString: `{"sales":[]}`},
+		{name: "error: limit > 500", path: "/trade-aggregator/v1/last-sales?gameId=a8db&title=a&limit=501",
+			wantHTTPCode: http.StatusBadRequest, wantBodyString: `"error":"BadRequest"`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(ts.URL + tc.path)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantHTTPCode, resp.StatusCode)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Contains(t, string(body), tc.wantBodyString)
+		})
+	}
+}
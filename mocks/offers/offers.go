@@ -0,0 +1,147 @@
+package offers
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/bxcodec/faker/v3"
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Params struct {
+	Offers []struct {
+		OfferID string                    `json:"OfferID"`
+		AssetID string                    `json:"AssetID"`
+		Price   *dmarket.MarketplacePrice `json:"Price"`
+	} `json:"Offers" binding:"required,min=1"`
+}
+
+// Market is an in-memory user offers storage shared by the create, edit and delete endpoints
+type Market struct {
+	mu     sync.Mutex
+	assets map[string]bool
+	offers map[string]dmarket.CreateOffer
+}
+
+// MustReturnSuccess creates a Market where the user inventory contains the given assets
+func MustReturnSuccess(assetIDs ...string) *Market {
+	m := &Market{assets: make(map[string]bool), offers: make(map[string]dmarket.CreateOffer)}
+	for _, id := range assetIDs {
+		m.assets[id] = true
+	}
+	return m
+}
+
+// Offer returns the placed offer by ID
+func (m *Market) Offer(offerID string) (dmarket.CreateOffer, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	offer, ok := m.offers[offerID]
+	return offer, ok
+}
+
+// Len returns the count of placed offers
+func (m *Market) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.offers)
+}
+
+// Create handles POST /marketplace-api/v1/user-offers/create
+func (m *Market) Create() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodPost, "/marketplace-api/v1/user-offers/create", m.handle(
+		func(offerID, assetID string, price *dmarket.MarketplacePrice) gin.H {
+			offer := dmarket.CreateOffer{AssetID: assetID}
+			if price != nil {
+				offer.Price = *price
+			}
+			result := gin.H{"CreateOffer": offer}
+			if err := m.validate(assetID, price); err != nil {
+				return failed(result, err)
+			}
+			id := faker.UUIDHyphenated()
+			m.offers[id] = offer
+			m.assets[assetID] = false
+			result["OfferID"] = id
+			result["Successful"] = true
+			return result
+		}))
+}
+
+// Edit handles POST /marketplace-api/v1/user-offers/edit
+func (m *Market) Edit() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodPost, "/marketplace-api/v1/user-offers/edit", m.handle(
+		func(offerID, assetID string, price *dmarket.MarketplacePrice) gin.H {
+			edit := dmarket.EditOffer{OfferID: offerID, AssetID: assetID}
+			if price != nil {
+				edit.Price = *price
+			}
+			result := gin.H{"EditOffer": edit}
+			offer, ok := m.offers[offerID]
+			if !ok {
+				return failed(result, &dmarket.MarketplaceError{Code: "OfferNotFound", Message: "offer not found"})
+			}
+			if price == nil || price.Amount <= 0 {
+				return failed(result, &dmarket.MarketplaceError{Code: "InvalidPrice", Message: "price must be positive"})
+			}
+			delete(m.offers, offerID)
+			offer.Price = *price
+			id := faker.UUIDHyphenated()
+			m.offers[id] = offer
+			result["NewOfferID"] = id
+			result["Successful"] = true
+			return result
+		}))
+}
+
+// Delete handles POST /marketplace-api/v1/user-offers/delete
+func (m *Market) Delete() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodPost, "/marketplace-api/v1/user-offers/delete", m.handle(
+		func(offerID, assetID string, _ *dmarket.MarketplacePrice) gin.H {
+			result := gin.H{"DeleteOffer": dmarket.DeleteOffer{OfferID: offerID, AssetID: assetID}}
+			offer, ok := m.offers[offerID]
+			if !ok {
+				return failed(result, &dmarket.MarketplaceError{Code: "OfferNotFound", Message: "offer not found"})
+			}
+			delete(m.offers, offerID)
+			m.assets[offer.AssetID] = true
+			result["Successful"] = true
+			return result
+		}))
+}
+
+func (m *Market) handle(process func(offerID, assetID string, price *dmarket.MarketplacePrice) gin.H) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		var params Params
+		if err := context.ShouldBindJSON(&params); err != nil {
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			return
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		results := make([]gin.H, 0, len(params.Offers))
+		for _, offer := range params.Offers {
+			results = append(results, process(offer.OfferID, offer.AssetID, offer.Price))
+		}
+		context.JSON(http.StatusOK, gin.H{"Result": results})
+	}
+}
+
+func (m *Market) validate(assetID string, price *dmarket.MarketplacePrice) *dmarket.MarketplaceError {
+	if listable, ok := m.assets[assetID]; !ok || !listable {
+		return &dmarket.MarketplaceError{Code: "AssetNotFound", Message: "asset not found in user inventory"}
+	}
+	if price == nil || price.Amount <= 0 {
+		return &dmarket.MarketplaceError{Code: "InvalidPrice", Message: "price must be positive"}
+	}
+	return nil
+}
+
+func failed(result gin.H, err *dmarket.MarketplaceError) gin.H {
+	result["Successful"] = false
+	result["Error"] = err
+	return result
+}
@@ -0,0 +1,67 @@
+package offers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/defernest/dmarket-go/mocks/offers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+func TestMarket(t *testing.T) {
+	market := offers.MustReturnSuccess("asset")
+	router := gin.New()
+	router.Handle(market.Create().Endpoint())
+	cases := []struct {
+		name           string
+		body           string
+		wantHTTPCode   int
+		wantBodyString string
+	}{
+		{
+			name:           "success",
+			body:           `{"Offers":[{"AssetID":"asset","Price":{"Currency":"USD","Amount":1.5}}]}`,
+			wantHTTPCode:   http.StatusOK,
+			wantBodyString: `"Successful":true`,
+		},
+		{
+			name:           "success: asset already on sale",
+			body:           `{"Offers":[{"AssetID":"asset","Price":{"Currency":"USD","Amount":1.5}}]}`,
+			wantHTTPCode:   http.StatusOK,
+			wantBodyString: `"Code":"AssetNotFound"`,
+		},
+		{
+			name:           "error: empty offers",
+			body:           `{"Offers":[]}`,
+			wantHTTPCode:   http.StatusBadRequest,
+			wantBodyString: `"error":"BadRequest"`,
+		},
+		{
+			name:           "error: bad body",
+			body:           `{`,
+			wantHTTPCode:   http.StatusBadRequest,
+			wantBodyString: `"error":"BadRequest"`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPost, "/marketplace-api/v1/user-offers/create", strings.NewReader(tc.body))
+			require.NoError(t, err)
+			router.ServeHTTP(w, req)
+			require.Equal(t, tc.wantHTTPCode, w.Code)
+			require.Contains(t, w.Body.String(), tc.wantBodyString)
+		})
+	}
+	require.Equal(t, 1, market.Len())
+}
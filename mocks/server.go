@@ -6,14 +6,17 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/common"
 
 	"golang.org/x/time/rate"
 
@@ -27,28 +30,51 @@ type DmarketEndpoint interface {
 type DmarketServer struct {
 	ts         *httptest.Server
 	Client     *dmarketClient
-	logs       *bytes.Buffer
+	logs       *logBuffer
+	limits     *dmarket.RateLimits
 	PrivareKey string
 	PublicKey  string
 }
 
-func NewDmarketServer(endpoint DmarketEndpoint) DmarketServer {
+// NewDmarketServer starts a mock Dmarket API server serving every given endpoint
+func NewDmarketServer(endpoints ...DmarketEndpoint) DmarketServer {
 	gin.SetMode(gin.TestMode)
-	var logs bytes.Buffer
+	var logs logBuffer
 	gin.DefaultWriter = &logs
 
+	limits := new(dmarket.RateLimits)
 	router := gin.New()
 	router.RedirectTrailingSlash = false
-	router.Use(gin.LoggerWithFormatter(logger()), rateLimit(), checkHeaders(), dmarketAuth())
+	router.Use(gin.LoggerWithConfig(gin.LoggerConfig{Formatter: logger(), Output: &logs}), rateLimit(limits), checkHeaders(), dmarketAuth())
 	router.NoRoute(noRoute())
-	router.Handle(endpoint.Endpoint())
+	for _, endpoint := range endpoints {
+		router.Handle(endpoint.Endpoint())
+	}
 
-	s := DmarketServer{ts: httptest.NewServer(router), logs: &logs}
+	s := DmarketServer{ts: httptest.NewServer(router), logs: &logs, limits: limits}
 	s.generateKeys()
 	s.Client = &dmarketClient{&s, rate.NewLimiter(5, 5)}
 	return s
 }
 
+// logBuffer is the server logs written by the concurrently served requests
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *logBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
 func (s DmarketServer) URL() string {
 	return s.ts.URL
 }
@@ -64,11 +90,19 @@ func (s DmarketServer) Close() {
 	defer s.ts.Close()
 }
 
-func rateLimit() gin.HandlerFunc {
+/*
+SetGroupRateLimit emulates the Dmarket rate limit of the endpoint group,
+requests over the limit are answered with 429 Too Many Requests
+*/
+func (s DmarketServer) SetGroupRateLimit(group dmarket.EndpointGroup, limit rate.Limit, burst int) {
+	s.limits.Set(group, limit, burst)
+}
+
+func rateLimit(limits *dmarket.RateLimits) gin.HandlerFunc {
 	limiter := rate.NewLimiter(10, 5)
 	return func(context *gin.Context) {
-		if !limiter.Allow() {
-			context.String(dmarket.ErrorRepresentation{Response: dmarket.Response{StatusCode: http.StatusTooManyRequests}}.String())
+		if !limiter.Allow() || !limits.Allow(dmarket.EndpointGroupOf(context.Request.URL.Path)) {
+			common.WriteError(context, http.StatusTooManyRequests, "", "too many requests, slow down")
 			context.Abort()
 		}
 	}
@@ -90,7 +124,7 @@ func logger() gin.LogFormatter {
 
 func noRoute() gin.HandlerFunc {
 	return func(context *gin.Context) {
-		context.String(dmarket.ErrorRepresentation{Response: dmarket.Response{StatusCode: http.StatusNotFound}}.String())
+		common.WriteError(context, http.StatusNotFound, "", fmt.Sprintf("no route to path '%s'", context.Request.RequestURI))
 		context.AbortWithError(http.StatusNotFound, fmt.Errorf("no route to path '%s'", context.Request.RequestURI))
 	}
 }
@@ -99,8 +133,9 @@ func checkHeaders() gin.HandlerFunc {
 	return func(context *gin.Context) {
 		pub, err := hex.DecodeString(context.GetHeader("X-Api-Key"))
 		if err != nil || len(pub) != 32 {
-			context.String(dmarket.ErrorRepresentation{Response: dmarket.Response{StatusCode: http.StatusBadRequest}}.String())
-			context.AbortWithError(http.StatusBadRequest, errors.New("X-Api-Key error: decode error or len not equal 32"))
+			err = errors.New("X-Api-Key error: decode error or len not equal 32")
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			context.AbortWithError(http.StatusBadRequest, err)
 			return
 		}
 		context.Set("X-Api-Key", pub)
@@ -109,23 +144,26 @@ func checkHeaders() gin.HandlerFunc {
 		containDMAR := strings.HasPrefix(signHeader, "dmar ed25519 ")
 		sign, err := hex.DecodeString(strings.TrimPrefix(signHeader, "dmar ed25519 "))
 		if err != nil || !containDMAR || len(sign) < 1 {
-			context.String(dmarket.ErrorRepresentation{Response: dmarket.Response{StatusCode: http.StatusBadRequest}}.String())
-			context.AbortWithError(http.StatusBadRequest, errors.New("X-Request-Sign error: decode error, not contain dmar prefix or len < 1"))
+			err = errors.New("X-Request-Sign error: decode error, not contain dmar prefix or len < 1")
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			context.AbortWithError(http.StatusBadRequest, err)
 			return
 		}
 		context.Set("X-Request-Sign", sign)
 
 		signdate, err := strconv.Atoi(context.GetHeader("X-Sign-Date"))
 		if err != nil || signdate <= 0 {
-			context.String(dmarket.ErrorRepresentation{Response: dmarket.Response{StatusCode: http.StatusBadRequest}}.String())
-			context.AbortWithError(http.StatusBadRequest, errors.New("X-Sign-Date error: atoi error or len <= 0"))
+			err = errors.New("X-Sign-Date error: atoi error or len <= 0")
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			context.AbortWithError(http.StatusBadRequest, err)
 			return
 		}
 		context.Set("X-Sign-Date", signdate)
 
 		if context.GetHeader("Accept") != "application/json" || context.GetHeader("Content-Type") != "application/json" {
-			context.String(dmarket.ErrorRepresentation{Response: dmarket.Response{StatusCode: http.StatusBadRequest}}.String())
-			context.AbortWithError(http.StatusBadRequest, errors.New("accept or content-Type headers not equal 'application/json'"))
+			err = errors.New("accept or content-Type headers not equal 'application/json'")
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			context.AbortWithError(http.StatusBadRequest, err)
 		}
 		return
 	}
@@ -137,14 +175,25 @@ func dmarketAuth() gin.HandlerFunc {
 		signdate := int64(context.GetInt("X-Sign-Date"))
 		pub, _ := context.Get("X-Api-Key")
 		sign, _ := context.Get("X-Request-Sign")
-		msg := []byte(context.Request.Method + context.Request.URL.String() + strconv.FormatInt(signdate, 10))
+		body, err := io.ReadAll(context.Request.Body)
+		if err != nil {
+			err = fmt.Errorf("request body read error: %w", err)
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			context.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
+		context.Request.Body = http.NoBody
+		if len(body) > 0 {
+			context.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		msg := []byte(context.Request.Method + context.Request.URL.String() + string(body) + strconv.FormatInt(signdate, 10))
 		if signdate > timestamp || !ed25519.Verify(pub.([]byte), msg, sign.([]byte)) {
 			_ = context.Error(errors.New("auth error")).
 				SetMeta(
 					fmt.Sprintf("X-Sign-Date correct: [%t] (false OK) | ed25519 verification: [%t] (true OK)",
 						signdate > timestamp, ed25519.Verify(pub.([]byte), msg, sign.([]byte))),
 				)
-			context.String(dmarket.ErrorRepresentation{Response: dmarket.Response{StatusCode: http.StatusUnauthorized}}.String())
+			common.WriteError(context, http.StatusUnauthorized, "InvalidSignature", "request signature verification failed")
 			context.AbortWithStatus(http.StatusUnauthorized)
 		}
 		return
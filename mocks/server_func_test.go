@@ -4,8 +4,12 @@ import (
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
+	"github.com/defernest/dmarket-go/dmarket"
 	"github.com/defernest/dmarket-go/mocks"
+	"github.com/defernest/dmarket-go/mocks/common"
+	"golang.org/x/time/rate"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
@@ -34,3 +38,19 @@ func TestDmarketServer_Close(t *testing.T) {
 	var urlerr *url.Error
 	require.ErrorAs(t, err, &urlerr)
 }
+
+func TestDmarketServer_SetGroupRateLimit(t *testing.T) {
+	ts := mocks.NewDmarketServer(testEndpoint{},
+		common.MustReturnStatusOK(http.MethodGet, "/exchange/v1/market/items"))
+	defer ts.Close()
+	ts.SetGroupRateLimit(dmarket.GroupMarket, rate.Every(time.Minute), 1)
+	resp, err := ts.Client.Get("/exchange/v1/market/items")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, err = ts.Client.Get("/exchange/v1/market/items")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	resp, err = ts.Client.Get("/")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
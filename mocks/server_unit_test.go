@@ -36,8 +36,8 @@ func Test_dmarketAuth(t *testing.T) {
 	ts := NewDmarketServer(testEndpoint{})
 	defer ts.Close()
 	cases := []struct {
-		name                                              string
-		wrongTimestamp, wrongPath, wrongPublic, wrongSign bool
+		name                                                         string
+		wrongTimestamp, wrongPath, wrongPublic, wrongSign, wrongBody bool
 	}{
 		{
 			name:           "wrong time",
@@ -67,15 +67,29 @@ func Test_dmarketAuth(t *testing.T) {
 			wrongPublic:    false,
 			wrongSign:      true,
 		},
+		{
+			name:      "wrong body",
+			wrongBody: true,
+		},
 	}
+	t.Run("control", func(t *testing.T) {
+		require.NoError(t, limiter.Wait(context.TODO()))
+		resp, err := ts.wrongGet(false, false, false, false, false)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, "{}", string(body))
+	})
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			resp, err := ts.wrongGet(tc.wrongTimestamp, tc.wrongPath, tc.wrongPublic, tc.wrongSign)
+			require.NoError(t, limiter.Wait(context.TODO()))
+			resp, err := ts.wrongGet(tc.wrongTimestamp, tc.wrongPath, tc.wrongPublic, tc.wrongSign, tc.wrongBody)
 			require.NoError(t, err)
 			require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
 			body, err := io.ReadAll(resp.Body)
 			require.NoError(t, err)
-			require.Equal(t, "401: Unauthorized", string(body))
+			require.Contains(t, string(body), `"error":"InvalidSignature"`)
 		})
 	}
 }
@@ -107,7 +121,7 @@ func Test_checkHeaders(t *testing.T) {
 				"X-Api-Key": {"7bf5f047bf"},
 			},
 			wantHTTPCode:   http.StatusBadRequest,
-			wantBodyString: "400: Bad Request",
+			wantBodyString: `"error":"BadRequest"`,
 		},
 		{
 			name: "public key error: decode error",
@@ -115,7 +129,7 @@ func Test_checkHeaders(t *testing.T) {
 				"X-Api-Key": {"e519f24bf3189604ef0db025451cbbaae0ed32820ed8d5f91ea0c6e74d1a5cccc"},
 			},
 			wantHTTPCode:   http.StatusBadRequest,
-			wantBodyString: "400: Bad Request",
+			wantBodyString: `"error":"BadRequest"`,
 		},
 		{
 			name: "sign error: empty sign",
@@ -124,7 +138,7 @@ func Test_checkHeaders(t *testing.T) {
 				"X-Request-Sign": {""},
 			},
 			wantHTTPCode:   http.StatusBadRequest,
-			wantBodyString: "400: Bad Request",
+			wantBodyString: `"error":"BadRequest"`,
 		},
 		{
 			name: "sign error: sign header without dmar",
@@ -133,7 +147,7 @@ func Test_checkHeaders(t *testing.T) {
 				"X-Request-Sign": {"d5d114a06d282855378a3d47b16bdb1293c9bb1a44c7271792ea2953d9772fc669294bb0fbd5e563e23dc981fc0bd933543c1502e7c48e3d95e6035135a1320b"},
 			},
 			wantHTTPCode:   http.StatusBadRequest,
-			wantBodyString: "400: Bad Request",
+			wantBodyString: `"error":"BadRequest"`,
 		},
 		{
 			name: "sign error: decode error",
@@ -142,7 +156,7 @@ func Test_checkHeaders(t *testing.T) {
 				"X-Request-Sign": {"d5d114a06d282855378a3d47b16bdb1293c9bb1a44c7271792ea2953d9772fc669294bb0fbd5e563e23dc981fc0bd933543c1502e7c48e3d95e6035135a1320"},
 			},
 			wantHTTPCode:   http.StatusBadRequest,
-			wantBodyString: "400: Bad Request",
+			wantBodyString: `"error":"BadRequest"`,
 		},
 		{
 			name: "sign date error: empty date",
@@ -152,7 +166,7 @@ func Test_checkHeaders(t *testing.T) {
 				"X-Sign-Date":    {""},
 			},
 			wantHTTPCode:   http.StatusBadRequest,
-			wantBodyString: "400: Bad Request",
+			wantBodyString: `"error":"BadRequest"`,
 		},
 		{
 			name: "sign date error: decode error",
@@ -162,7 +176,7 @@ func Test_checkHeaders(t *testing.T) {
 				"X-Sign-Date":    {"163369726ERR"},
 			},
 			wantHTTPCode:   http.StatusBadRequest,
-			wantBodyString: "400: Bad Request",
+			wantBodyString: `"error":"BadRequest"`,
 		},
 		{
 			name: "accept or content-type empty",
@@ -172,7 +186,7 @@ func Test_checkHeaders(t *testing.T) {
 				"X-Sign-Date":    {"1633697260"},
 			},
 			wantHTTPCode:   http.StatusBadRequest,
-			wantBodyString: "400: Bad Request",
+			wantBodyString: `"error":"BadRequest"`,
 		},
 	}
 	for _, tc := range cases {
@@ -185,7 +199,7 @@ func Test_checkHeaders(t *testing.T) {
 			require.Equal(t, tc.wantHTTPCode, resp.StatusCode)
 			body, err := io.ReadAll(resp.Body)
 			require.NoError(t, err)
-			require.Equal(t, tc.wantBodyString, string(body))
+			require.Contains(t, string(body), tc.wantBodyString)
 		})
 	}
 }
@@ -199,5 +213,5 @@ func Test_noRoute(t *testing.T) {
 	require.Equal(t, http.StatusNotFound, resp.StatusCode)
 	body, err := io.ReadAll(resp.Body)
 	require.NoError(t, err)
-	require.Equal(t, "404: Not Found", string(body))
+	require.Contains(t, string(body), `"error":"NotFound"`)
 }
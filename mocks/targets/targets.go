@@ -0,0 +1,171 @@
+package targets
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/bxcodec/faker/v3"
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CreateParams struct {
+	GameID  string                 `json:"GameID" binding:"required"`
+	Targets []dmarket.CreateTarget `json:"Targets" binding:"required,min=1"`
+}
+
+type ListParams struct {
+	GameID string `form:"GameID" binding:"required"`
+	Status string `form:"BasicFilters.Status"`
+	Limit  int    `form:"Limit" binding:"gte=0,lte=100"`
+	Cursor string `form:"Cursor"`
+}
+
+type DeleteParams struct {
+	Targets []struct {
+		TargetID string `json:"TargetID"`
+	} `json:"Targets" binding:"required,min=1"`
+}
+
+// Store is an in-memory user targets storage shared by the create, list and delete endpoints
+type Store struct {
+	mu      sync.Mutex
+	targets []dmarket.Target
+}
+
+// MustReturnSuccess creates a Store which already contains the given targets
+func MustReturnSuccess(targets ...dmarket.Target) *Store {
+	return &Store{targets: targets}
+}
+
+// Len returns the count of stored targets
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.targets)
+}
+
+// Create handles POST /marketplace-api/v1/user-targets/create
+func (s *Store) Create() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodPost, "/marketplace-api/v1/user-targets/create", func(context *gin.Context) {
+		var params CreateParams
+		if err := context.ShouldBindJSON(&params); err != nil {
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		results := make([]dmarket.CreateTargetResult, 0, len(params.Targets))
+		for _, target := range params.Targets {
+			result := dmarket.CreateTargetResult{CreateTarget: target}
+			switch {
+			case target.Title == "":
+				result.Error = &dmarket.MarketplaceError{Code: "InvalidTitle", Message: "title is required"}
+			case target.Price.Amount <= 0:
+				result.Error = &dmarket.MarketplaceError{Code: "InvalidPrice", Message: "price must be positive"}
+			default:
+				result.TargetID = faker.UUIDHyphenated()
+				result.Successful = true
+				s.targets = append(s.targets, dmarket.Target{
+					TargetID:   result.TargetID,
+					Title:      target.Title,
+					Amount:     int64(target.Amount),
+					Status:     dmarket.TargetStatusActive,
+					GameID:     params.GameID,
+					Price:      target.Price,
+					Attributes: attributes(target.Attrs),
+				})
+			}
+			results = append(results, result)
+		}
+		context.JSON(http.StatusOK, dmarket.CreateTargetsResponse{Result: results})
+	})
+}
+
+// List handles GET /marketplace-api/v1/user-targets, the cursor is an offset of the next page
+func (s *Store) List() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodGet, "/marketplace-api/v1/user-targets", func(context *gin.Context) {
+		var params ListParams
+		err := context.ShouldBindQuery(&params)
+		offset, cursorErr := strconv.Atoi(params.Cursor)
+		if params.Cursor == "" {
+			offset, cursorErr = 0, nil
+		}
+		if err != nil {
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			return
+		}
+		if cursorErr != nil || offset < 0 {
+			common.WriteError(context, http.StatusBadRequest, "", fmt.Sprintf("invalid cursor %q", params.Cursor))
+			return
+		}
+		if params.Limit == 0 {
+			params.Limit = 100
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		var filtered []dmarket.Target
+		for _, target := range s.targets {
+			if target.GameID == params.GameID && (params.Status == "" || string(target.Status) == params.Status) {
+				filtered = append(filtered, target)
+			}
+		}
+		resp := dmarket.UserTargetsResponse{Total: int64(len(filtered)), Items: []dmarket.Target{}}
+		if offset < len(filtered) {
+			end := offset + params.Limit
+			if end < len(filtered) {
+				resp.Cursor = strconv.Itoa(end)
+			} else {
+				end = len(filtered)
+			}
+			resp.Items = filtered[offset:end]
+		}
+		context.JSON(http.StatusOK, &resp)
+	})
+}
+
+// Delete handles POST /marketplace-api/v1/user-targets/delete
+func (s *Store) Delete() *common.EndpointBehavior {
+	return common.NewEndpointBehavior(http.MethodPost, "/marketplace-api/v1/user-targets/delete", func(context *gin.Context) {
+		var params DeleteParams
+		if err := context.ShouldBindJSON(&params); err != nil {
+			common.WriteError(context, http.StatusBadRequest, "", err.Error())
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		results := make([]dmarket.DeleteTargetResult, 0, len(params.Targets))
+		for _, target := range params.Targets {
+			var result dmarket.DeleteTargetResult
+			result.DeleteTarget.TargetID = target.TargetID
+			result.Error = &dmarket.MarketplaceError{Code: "TargetNotFound", Message: "target not found"}
+			for i := range s.targets {
+				if s.targets[i].TargetID == target.TargetID {
+					s.targets = append(s.targets[:i], s.targets[i+1:]...)
+					result.Successful, result.Error = true, nil
+					break
+				}
+			}
+			results = append(results, result)
+		}
+		context.JSON(http.StatusOK, dmarket.DeleteTargetsResponse{Result: results})
+	})
+}
+
+func attributes(attrs dmarket.TargetAttributes) []dmarket.TargetAttribute {
+	var result []dmarket.TargetAttribute
+	if attrs.FloatPartValue != "" {
+		result = append(result, dmarket.TargetAttribute{Name: "floatPartValue", Value: attrs.FloatPartValue})
+	}
+	if attrs.PaintSeed != 0 {
+		result = append(result, dmarket.TargetAttribute{Name: "paintSeed", Value: strconv.Itoa(attrs.PaintSeed)})
+	}
+	if attrs.Phase != "" {
+		result = append(result, dmarket.TargetAttribute{Name: "phase", Value: attrs.Phase})
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+package targets_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks/targets"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+func TestStore_List(t *testing.T) {
+	store := targets.MustReturnSuccess(
+		dmarket.Target{TargetID: "1", GameID: "a8db", Status: dmarket.TargetStatusActive},
+		dmarket.Target{TargetID: "2", GameID: "a8db", Status: dmarket.TargetStatusInactive},
+		dmarket.Target{TargetID: "3", GameID: "a8db", Status: dmarket.TargetStatusActive},
+		dmarket.Target{TargetID: "4", GameID: "9a92", Status: dmarket.TargetStatusActive},
+	)
+	router := gin.New()
+	router.Handle(store.List().Endpoint())
+	cases := []struct {
+		name         string
+		query        string
+		wantHTTPCode int
+		wantIDs      []string
+		wantCursor   string
+	}{
+		{name: "success: game filter", query: "GameID=a8db", wantHTTPCode: http.StatusOK, wantIDs: []string{"1", "2", "3"}},
+		{name: "success: status filter", query: "GameID=a8db&BasicFilters.Status=TargetStatusActive", wantHTTPCode: http.StatusOK, wantIDs: []string{"1", "3"}},
+		{name: "success: first page", query: "GameID=a8db&Limit=2", wantHTTPCode: http.StatusOK, wantIDs: []string{"1", "2"}, wantCursor: "2"},
+		{name: "success: last page", query: "GameID=a8db&Limit=2&Cursor=2", wantHTTPCode: http.StatusOK, wantIDs: []string{"3"}},
+		{name: "error: no GameID", query: "Limit=2", wantHTTPCode: http.StatusBadRequest},
+		{name: "error: bad cursor", query: "GameID=a8db&Cursor=x", wantHTTPCode: http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/marketplace-api/v1/user-targets?"+tc.query, nil)
+			require.NoError(t, err)
+			router.ServeHTTP(w, req)
+			require.Equal(t, tc.wantHTTPCode, w.Code)
+			if tc.wantHTTPCode != http.StatusOK {
+				return
+			}
+			var resp dmarket.UserTargetsResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			var ids []string
+			for _, target := range resp.Items {
+				ids = append(ids, target.TargetID)
+			}
+			require.Equal(t, tc.wantIDs, ids)
+			require.Equal(t, tc.wantCursor, resp.Cursor)
+		})
+	}
+}
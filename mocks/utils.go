@@ -1,6 +1,7 @@
 package mocks
 
 import (
+	"bytes"
 	"context"
 	"crypto/ed25519"
 	"crypto/rand"
@@ -10,6 +11,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -21,36 +23,60 @@ type dmarketClient struct {
 }
 
 func (c dmarketClient) Get(endpoint string) (dmarket.Response, error) {
-	req, err := http.NewRequest(http.MethodGet, c.server.URL()+endpoint, http.NoBody)
-	if err != nil {
-		return dmarket.Response{}, err
-	}
-	return c.Do(req)
+	return c.GetContext(context.Background(), endpoint)
 }
 
 func (c dmarketClient) Post(endpoint string, body io.Reader) (dmarket.Response, error) {
-	req, err := http.NewRequest(http.MethodGet, c.server.URL()+endpoint, body)
-	if err != nil {
-		return dmarket.Response{}, err
-	}
-	return c.Do(req)
+	return c.PostContext(context.Background(), endpoint, body)
 }
 
 func (c dmarketClient) Delete(endpoint string, body io.Reader) (dmarket.Response, error) {
-	panic(fmt.Errorf("implement me %s, %v", endpoint, body))
+	return c.DeleteContext(context.Background(), endpoint, body)
 }
 
 func (c dmarketClient) Patch(endpoint string, body io.Reader) (dmarket.Response, error) {
-	panic(fmt.Errorf("implement me %s, %v", endpoint, body))
+	return c.PatchContext(context.Background(), endpoint, body)
+}
+
+func (c dmarketClient) GetContext(ctx context.Context, endpoint string) (dmarket.Response, error) {
+	return c.request(ctx, http.MethodGet, endpoint, http.NoBody)
+}
+
+func (c dmarketClient) PostContext(ctx context.Context, endpoint string, body io.Reader) (dmarket.Response, error) {
+	return c.request(ctx, http.MethodPost, endpoint, body)
+}
+
+func (c dmarketClient) DeleteContext(ctx context.Context, endpoint string, body io.Reader) (dmarket.Response, error) {
+	return c.request(ctx, http.MethodDelete, endpoint, body)
+}
+
+func (c dmarketClient) PatchContext(ctx context.Context, endpoint string, body io.Reader) (dmarket.Response, error) {
+	return c.request(ctx, http.MethodPatch, endpoint, body)
+}
+
+func (c dmarketClient) request(ctx context.Context, method, endpoint string, body io.Reader) (dmarket.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.server.URL()+endpoint, body)
+	if err != nil {
+		return dmarket.Response{}, err
+	}
+	return c.Do(req)
 }
 
 func (c dmarketClient) Do(req *http.Request) (dmarket.Response, error) {
-	err := c.rateLimit.Wait(context.TODO())
+	err := c.rateLimit.Wait(req.Context())
 	if err != nil {
 		return dmarket.Response{}, fmt.Errorf("api: request rate limiter error: %w", err)
 	}
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return dmarket.Response{}, fmt.Errorf("api mock: read request body error: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
 	timestamp := strconv.Itoa(int(time.Now().UTC().Unix()))
-	signature, err := c.server.sign(req.Method, req.URL.RequestURI(), timestamp)
+	signature, err := c.server.sign(req.Method, req.URL.RequestURI(), string(body), timestamp)
 	if err != nil {
 		return dmarket.Response{}, fmt.Errorf("api mock: new request sign error: %w", err)
 	}
@@ -95,32 +121,37 @@ To make a signature, take the following steps:
        sign it with ed25519 using you secret key.
     3. Encode the result string with hex
 */
-func (s DmarketServer) sign(method, path, timestamp string) (string, error) {
+func (s DmarketServer) sign(method, path, body, timestamp string) (string, error) {
 	b, err := hex.DecodeString(s.PrivareKey)
 	if err != nil {
 		return "", fmt.Errorf("api: sign decode string error: %w", err)
 	}
 	var privateKey [64]byte
 	copy(privateKey[:], b[:64])
-	sign := hex.EncodeToString(ed25519.Sign(privateKey[:], []byte(method+path+timestamp)))
+	sign := hex.EncodeToString(ed25519.Sign(privateKey[:], []byte(method+path+body+timestamp)))
 	return sign, nil
 }
 
-func (s DmarketServer) wrongGet(wrongTimestamp, wrongPath, wrongPublic, wrongSign bool) (*http.Response, error) {
+// wrongGet requests the root endpoint signed for the path "/" and the body "{}", every wrong flag tampers only its part of the request
+func (s DmarketServer) wrongGet(wrongTimestamp, wrongPath, wrongPublic, wrongSign, wrongBody bool) (*http.Response, error) {
 	pub := s.PublicKey
-	path := s.URL()
+	path := "/"
 	timestamp := strconv.Itoa(int(time.Now().UTC().Unix()))
 	if wrongTimestamp {
 		timestamp = strconv.Itoa(int(time.Now().Add(1 * time.Hour).UTC().Unix()))
 	}
-	if wrongPath {
-		path = s.URL() + "/err"
-	}
-	signature, err := s.sign(http.MethodGet, s.URL(), timestamp)
+	signature, err := s.sign(http.MethodGet, path, "{}", timestamp)
 	if err != nil {
 		return nil, fmt.Errorf("api: new request sign error: %w", err)
 	}
-	req, err := http.NewRequest(http.MethodGet, path, http.NoBody)
+	if wrongPath {
+		path = "/err"
+	}
+	body := "{}"
+	if wrongBody {
+		body = `{"tampered":true}`
+	}
+	req, err := http.NewRequest(http.MethodGet, s.URL()+path, strings.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("error creating dmarket request (%w)", err)
 	}
@@ -132,8 +163,12 @@ func (s DmarketServer) wrongGet(wrongTimestamp, wrongPath, wrongPublic, wrongSig
 	}
 	req.Header.Set("X-Request-Sign", "dmar ed25519 "+signature)
 	if wrongPublic {
-		pub = pub[2:]
+		other, _, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			return nil, fmt.Errorf("api: generate public key error: %w", err)
+		}
+		pub = hex.EncodeToString(other)
 	}
-	req.Header.Set("X-Api-Key", s.PublicKey)
+	req.Header.Set("X-Api-Key", pub)
 	return http.DefaultClient.Do(req)
 }
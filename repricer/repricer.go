@@ -0,0 +1,250 @@
+/*
+Package repricer keeps the user sell offers priced by the Strategy against the competing offers of the same title.
+
+	r, err := repricer.New(client, repricer.Config{
+		Game:     dmarket.GameCSGO,
+		Strategy: repricer.FloorCeiling(repricer.Undercut(1), 100, 0),
+		DryRun:   true,
+	})
+	report, err := r.Run(ctx)
+
+The offers are read from the user inventory (see Items.GetAllItemsFromUserInventory),
+the competitors are the market offers with exactly the same title (see Items.GetAllItemsFromDmarket and ItemsExactTitle)
+and the changed prices are sent by Offers.Edit in batches.
+*/
+package repricer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/defernest/dmarket-go/dmarket"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// ErrConfig indicates an incorrect Config of New
+var ErrConfig = errors.New("incorrect repricer config")
+
+/*
+Config sets the repricing of the user offers
+
+	Game      - the game of the offers, dmarket.DefaultGame by default
+	Strategy  - the pricing strategy, required
+	BatchSize - the count of the offers per Offers.Edit request from 1 to 100, 100 by default
+	Depth     - the max count of the cheapest competitors passed to the strategy, the own offers are not counted, 100 by default
+	DryRun    - the changes are planned and reported, but not sent
+*/
+type Config struct {
+	Game      dmarket.Game
+	Strategy  Strategy
+	BatchSize int
+	Depth     int
+	DryRun    bool
+}
+
+// Repricer reprices the user offers, see Config
+type Repricer struct {
+	exchange *dmarket.Exchange
+	config   Config
+}
+
+// New create new Repricer of the user offers
+func New(client dmarket.Requester, config Config) (*Repricer, error) {
+	if config.Strategy == nil {
+		return nil, fmt.Errorf("%w: strategy is required", ErrConfig)
+	}
+	if config.Game == "" {
+		config.Game = dmarket.DefaultGame
+	}
+	if config.BatchSize == 0 {
+		config.BatchSize = 100
+	}
+	if config.BatchSize < 0 || config.BatchSize > 100 {
+		return nil, fmt.Errorf("%w: batch size %d => 0 < batch size <= 100", ErrConfig, config.BatchSize)
+	}
+	if config.Depth == 0 {
+		config.Depth = 100
+	}
+	if config.Depth < 0 {
+		return nil, fmt.Errorf("%w: depth %d => depth > 0", ErrConfig, config.Depth)
+	}
+	return &Repricer{exchange: dmarket.NewExchange(client), config: config}, nil
+}
+
+/*
+Change is the new price of the user offer
+
+	OfferID            - the repriced offer, NewOfferID is the offer created by the edit
+	OldPrice, NewPrice - the USD price before and the USD price computed by the strategy
+	Error              - the failure of the edit reported by Dmarket
+*/
+type Change struct {
+	OfferID    string
+	NewOfferID string
+	AssetID    string
+	Title      string
+	OldPrice   dmarket.Cents
+	NewPrice   dmarket.Cents
+	Error      error
+}
+
+/*
+Report is the result of Repricer.Run
+
+	Changes - the planned (DryRun) or sent changes in the order of the user inventory
+	Kept    - the count of the offers which price is not changed
+*/
+type Report struct {
+	Changes []Change
+	Kept    int
+	DryRun  bool
+}
+
+// Err reports the changes that Dmarket did not apply, see dmarket.EditOffersResponse.Err
+func (r Report) Err() error {
+	var errs error
+	for _, change := range r.Changes {
+		if change.Error != nil {
+			errs = multierror.Append(errs, fmt.Errorf("offer %s: %w", change.OfferID, change.Error))
+		}
+	}
+	return errs
+}
+
+/*
+Run reprices the user offers once. The returned error reports the request failures,
+the changes planned before the failure are returned with it, the failures of the edits are available with Report.Err.
+*/
+func (r Repricer) Run(ctx context.Context) (*Report, error) {
+	report := &Report{DryRun: r.config.DryRun}
+	listings, err := r.listings(ctx)
+	if err != nil {
+		return report, fmt.Errorf("repricer: user offers error: %w", err)
+	}
+	own := make(map[string]bool, len(listings))
+	for _, listing := range listings {
+		own[listing.Extra.OfferID] = true
+	}
+	competitors := make(map[string][]dmarket.Object)
+	for _, listing := range listings {
+		others, ok := competitors[listing.Title]
+		if !ok {
+			if others, err = r.competitors(ctx, listing.Title, own); err != nil {
+				return report, fmt.Errorf("repricer: competitors of %q error: %w", listing.Title, err)
+			}
+			competitors[listing.Title] = others
+		}
+		price, ok := r.config.Strategy.Price(Quote{Listing: listing, Competitors: others})
+		if !ok || price <= 0 || price == listing.Price.Usd {
+			report.Kept++
+			continue
+		}
+		report.Changes = append(report.Changes, Change{
+			OfferID:  listing.Extra.OfferID,
+			AssetID:  listing.ItemID,
+			Title:    listing.Title,
+			OldPrice: listing.Price.Usd,
+			NewPrice: price,
+		})
+	}
+	if r.config.DryRun {
+		return report, nil
+	}
+	for start := 0; start < len(report.Changes); start += r.config.BatchSize {
+		end := start + r.config.BatchSize
+		if end > len(report.Changes) {
+			end = len(report.Changes)
+		}
+		if err = r.edit(ctx, report.Changes[start:end]); err != nil {
+			return report, fmt.Errorf("repricer: %w", err)
+		}
+	}
+	return report, nil
+}
+
+// listings returns the user inventory objects placed on the market
+func (r Repricer) listings(ctx context.Context) ([]dmarket.Object, error) {
+	pager, err := r.exchange.Items.PagerFromUserInventory(dmarket.ItemsGame(r.config.Game))
+	if err != nil {
+		return nil, err
+	}
+	objects, err := pager.CollectAll(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	listings := objects[:0]
+	for _, object := range objects {
+		if object.Extra.OfferID != "" {
+			listings = append(listings, object)
+		}
+	}
+	return listings, nil
+}
+
+// competitors returns the cheapest Depth market offers with exactly the title except the own offers
+func (r Repricer) competitors(ctx context.Context, title string, own map[string]bool) ([]dmarket.Object, error) {
+	if title == "" {
+		return nil, nil
+	}
+	pager, err := r.exchange.Items.PagerFromDmarket(
+		dmarket.ItemsGame(r.config.Game),
+		dmarket.ItemsExactTitle(title),
+		dmarket.ItemsOrder(dmarket.OrderByPrice, dmarket.OrderAsc),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var others []dmarket.Object
+	for len(others) < r.config.Depth && pager.Next(ctx) {
+		for _, object := range pager.Page().Objects {
+			if object.Title == title && !own[object.Extra.OfferID] && object.Price.Usd > 0 {
+				others = append(others, object)
+			}
+		}
+	}
+	if err = pager.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(others, func(i, j int) bool { return others[i].Price.Usd < others[j].Price.Usd })
+	if len(others) > r.config.Depth {
+		others = others[:r.config.Depth]
+	}
+	return others, nil
+}
+
+// edit sends the batch of the changes and sets the results of the edits
+func (r Repricer) edit(ctx context.Context, changes []Change) error {
+	edits := make([]dmarket.EditOffer, 0, len(changes))
+	for _, change := range changes {
+		edits = append(edits, dmarket.EditOffer{
+			OfferID: change.OfferID,
+			AssetID: change.AssetID,
+			Price:   dmarket.MarketplacePrice{Currency: string(dmarket.CurrencyUSD), Amount: int64(change.NewPrice)},
+		})
+	}
+	resp, err := r.exchange.Offers.Edit(ctx, edits...)
+	if err != nil {
+		return err
+	}
+	results := make(map[string]dmarket.EditOfferResult, len(resp.Result))
+	for _, result := range resp.Result {
+		results[result.EditOffer.OfferID] = result
+	}
+	for i := range changes {
+		result, ok := results[changes[i].OfferID]
+		switch {
+		case !ok:
+			changes[i].Error = dmarket.MarketplaceError{Code: "Unknown", Message: "offer is missing in the edit result"}
+		case !result.Successful && result.Error != nil:
+			changes[i].Error = *result.Error
+		case !result.Successful:
+			changes[i].Error = dmarket.MarketplaceError{Code: "Unknown", Message: "operation was not successful"}
+		default:
+			changes[i].NewOfferID = result.NewOfferID
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package repricer
+
+import (
+	"testing"
+
+	"github.com/defernest/dmarket-go/dmarket"
+
+	"github.com/stretchr/testify/require"
+)
+
+func quote(price dmarket.Cents, competitors ...dmarket.Cents) Quote {
+	q := Quote{Listing: dmarket.Object{Price: dmarket.Price{Usd: price}}}
+	q.Listing.RecommendedPrice.D7.Usd = 150
+	for _, c := range competitors {
+		q.Competitors = append(q.Competitors, dmarket.Object{Price: dmarket.Price{Usd: c}})
+	}
+	return q
+}
+
+func TestStrategies(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy Strategy
+		quote    Quote
+		price    dmarket.Cents
+		ok       bool
+	}{
+		{name: "undercut", strategy: Undercut(5), quote: quote(200, 120, 130), price: 115, ok: true},
+		{name: "undercut raises the cheapest", strategy: Undercut(5), quote: quote(50, 120), price: 115, ok: true},
+		{name: "undercut without competitors", strategy: Undercut(5), quote: quote(200)},
+		{name: "match", strategy: Match(), quote: quote(200, 120), price: 120, ok: true},
+		{name: "recommended", strategy: Recommended(), quote: quote(200), price: 150, ok: true},
+		{name: "recommended without price", strategy: Recommended(), quote: Quote{}},
+		{name: "floor", strategy: FloorCeiling(Undercut(5), 118, 0), quote: quote(200, 120), price: 118, ok: true},
+		{name: "ceiling", strategy: FloorCeiling(Undercut(5), 0, 100), quote: quote(200, 120), price: 100, ok: true},
+		{name: "floor keeps", strategy: FloorCeiling(Undercut(5), 118, 0), quote: quote(200)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, ok := tt.strategy.Price(tt.quote)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.price, price)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	r, err := New(nil, Config{Strategy: Match()})
+	require.NoError(t, err)
+	require.Equal(t, dmarket.DefaultGame, r.config.Game)
+	require.Equal(t, 100, r.config.BatchSize)
+	require.Equal(t, 100, r.config.Depth)
+
+	for _, config := range []Config{{}, {Strategy: Match(), BatchSize: 101}, {Strategy: Match(), Depth: -1}} {
+		_, err = New(nil, config)
+		require.ErrorIs(t, err, ErrConfig)
+	}
+}
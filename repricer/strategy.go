@@ -0,0 +1,80 @@
+package repricer
+
+import "github.com/defernest/dmarket-go/dmarket"
+
+/*
+Quote is the input of the Strategy for one listing
+
+	Listing     - the user offer, the current price is Listing.Price.Usd
+	Competitors - the other offers of the same title sorted by the USD price, the user offers are excluded
+*/
+type Quote struct {
+	Listing     dmarket.Object
+	Competitors []dmarket.Object
+}
+
+// Lowest returns the lowest competitor price, false when there are no competitors
+func (q Quote) Lowest() (dmarket.Cents, bool) {
+	if len(q.Competitors) == 0 {
+		return 0, false
+	}
+	return q.Competitors[0].Price.Usd, true
+}
+
+// Strategy computes the new USD price of the listing, false keeps the current price
+type Strategy interface {
+	Price(quote Quote) (dmarket.Cents, bool)
+}
+
+// StrategyFunc is an adapter to use the function as the Strategy
+type StrategyFunc func(quote Quote) (dmarket.Cents, bool)
+
+// Price calls f(quote)
+func (f StrategyFunc) Price(quote Quote) (dmarket.Cents, bool) {
+	return f(quote)
+}
+
+// Undercut prices the listing step cents below the lowest competitor, the listing without competitors is kept
+func Undercut(step dmarket.Cents) Strategy {
+	return StrategyFunc(func(quote Quote) (dmarket.Cents, bool) {
+		lowest, ok := quote.Lowest()
+		if !ok {
+			return 0, false
+		}
+		return lowest - step, true
+	})
+}
+
+// Match prices the listing equal to the lowest competitor, the listing without competitors is kept
+func Match() Strategy {
+	return Undercut(0)
+}
+
+// Recommended prices the listing by the 7 days recommended price of Dmarket, the listing without it is kept
+func Recommended() Strategy {
+	return StrategyFunc(func(quote Quote) (dmarket.Cents, bool) {
+		price := quote.Listing.RecommendedPrice.D7.Usd
+		return price, price > 0
+	})
+}
+
+/*
+FloorCeiling limits the price of the strategy from floor to ceiling inclusive, zero ceiling is not limited.
+
+	FloorCeiling(Undercut(1), 500, 0) - the cheapest by 1 cent, but never below $5
+*/
+func FloorCeiling(strategy Strategy, floor, ceiling dmarket.Cents) Strategy {
+	return StrategyFunc(func(quote Quote) (dmarket.Cents, bool) {
+		price, ok := strategy.Price(quote)
+		if !ok {
+			return 0, false
+		}
+		if ceiling > 0 && price > ceiling {
+			price = ceiling
+		}
+		if price < floor {
+			price = floor
+		}
+		return price, true
+	})
+}
@@ -0,0 +1,75 @@
+package tests_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks"
+	"github.com/defernest/dmarket-go/mocks/account"
+	"github.com/defernest/dmarket-go/mocks/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccount(t *testing.T) {
+	fees := dmarket.FeesResponse{DefaultFee: dmarket.Fee{Fraction: 0.1, MinAmount: 1}}
+	for i := 0; i < 250; i++ {
+		fees.ReducedFees = append(fees.ReducedFees, dmarket.ReducedFee{Title: "title " + strconv.Itoa(i), Fraction: 0.02, MaxPrice: 10000})
+	}
+	mock := account.MustReturnSuccess(
+		dmarket.Balance{USD: 12345, USDAvailableToWithdraw: 10000, DMC: 500},
+		dmarket.User{ID: "user", Username: "trader", SteamAccount: dmarket.SteamAccount{SteamID: "7656"}},
+		fees,
+	)
+	ts := mocks.NewDmarketServer(mock.Balance(), mock.User(), mock.Fees())
+	defer ts.Close()
+	a := dmarket.NewAccount(ts.Client)
+
+	t.Run("balance", func(t *testing.T) {
+		balance, err := a.Balance(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, dmarket.Cents(12345), balance.USD)
+		require.Equal(t, "123.45", balance.USD.String())
+		require.Equal(t, dmarket.Cents(10000), balance.USDAvailableToWithdraw)
+		require.Equal(t, dmarket.Cents(500), balance.DMC)
+
+		mock.SetBalance(dmarket.Balance{USD: 1})
+		balance, err = a.Balance(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, dmarket.Cents(1), balance.USD)
+	})
+	t.Run("user", func(t *testing.T) {
+		user, err := a.User(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "trader", user.Username)
+		require.Equal(t, "7656", user.SteamAccount.SteamID)
+	})
+	t.Run("fees", func(t *testing.T) {
+		resp, err := a.Fees(context.Background(), "a8db")
+		require.NoError(t, err)
+		require.Equal(t, 0.1, resp.DefaultFee.Fraction)
+		require.Equal(t, dmarket.Cents(1), resp.DefaultFee.MinAmount)
+		require.Len(t, resp.ReducedFees, 250)
+		require.Equal(t, 250, resp.Total)
+	})
+	t.Run("fee table", func(t *testing.T) {
+		table, err := a.FeeTable(context.Background(), dmarket.GameCSGO)
+		require.NoError(t, err)
+		require.Len(t, table.Reduced[dmarket.GameCSGO], 250)
+		reduced := dmarket.Object{GameID: string(dmarket.GameCSGO), Title: "title 7"}
+		require.Equal(t, dmarket.Cents(980), table.Net(reduced, 1000))
+		require.Equal(t, dmarket.Cents(900), table.Net(dmarket.Object{GameID: string(dmarket.GameCSGO), Title: "other"}, 1000))
+		price, err := table.ListPrice(reduced, 980)
+		require.NoError(t, err)
+		require.Equal(t, dmarket.Cents(1000), price)
+	})
+	t.Run("error: http error", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(common.MustReturnHTTPError(http.MethodGet, "/account/v1/balance", http.StatusUnauthorized))
+		defer ts.Close()
+		_, err := dmarket.NewAccount(ts.Client).Balance(context.Background())
+		require.ErrorAs(t, err, &dmarket.ErrorRepresentation{})
+	})
+}
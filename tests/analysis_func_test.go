@@ -0,0 +1,51 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/defernest/dmarket-go/analysis"
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks"
+	"github.com/defernest/dmarket-go/mocks/items"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetector_MockAndSnapshot(t *testing.T) {
+	catalog := items.Catalog(250, 10000)
+	for i := range catalog {
+		catalog[i].RecommendedPrice.D7.Usd = catalog[i].Price.Usd * dmarket.Cents(1+i%3)
+	}
+	ts := mocks.NewDmarketServer(items.MustReturnCatalog(catalog))
+	defer ts.Close()
+	exchange := dmarket.NewExchange(ts.Client)
+	detector, err := analysis.NewDetector(analysis.Config{
+		Fraction: 0.8,
+		Net:      dmarket.FeeTable{Default: dmarket.Fee{Fraction: 0.1, MinAmount: 1}}.Net,
+	})
+	require.NoError(t, err)
+
+	pages, err := exchange.Items.GetAllItemsFromDmarket(context.Background(), dmarket.ItemsLimitPerRequest(40))
+	require.NoError(t, err)
+	online, err := detector.Collect(context.Background(), pages)
+	require.NoError(t, err)
+	require.NotEmpty(t, online)
+	for i, candidate := range online {
+		require.Less(t, float64(candidate.Object.Price.Usd), 0.8*float64(candidate.Net))
+		if i > 0 {
+			require.LessOrEqual(t, candidate.Margin, online[i-1].Margin)
+		}
+	}
+
+	var snapshot bytes.Buffer
+	pages, err = exchange.Items.GetAllItemsFromDmarket(context.Background(), dmarket.ItemsLimitPerRequest(40))
+	require.NoError(t, err)
+	saved, err := analysis.SaveSnapshot(&snapshot, pages)
+	require.NoError(t, err)
+	require.Equal(t, 7, saved)
+	offline, err := detector.Collect(context.Background(), analysis.LoadSnapshot(&snapshot))
+	require.NoError(t, err)
+	require.Equal(t, online, offline)
+}
@@ -1,17 +1,23 @@
 package tests
 
 import (
+	"context"
 	"encoding/hex"
 	"github.com/defernest/dmarket-go/mocks"
+	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/defernest/dmarket-go/dmarket"
 	"github.com/defernest/dmarket-go/mocks/common"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 )
 
 func TestDefaultClient_Do(t *testing.T) {
@@ -64,6 +70,10 @@ func Test_DefaultClient_Delete(t *testing.T) {
 	resp, err := apiClient.DefaultClient.Delete("/", payload)
 	require.NoError(t, err)
 	require.Equal(t, "{}", resp.Body.String())
+
+	resp, err = apiClient.DefaultClient.DeleteContext(context.Background(), "/", nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
 func Test_DefaultClient_Get(t *testing.T) {
@@ -96,3 +106,230 @@ func Test_DefaultClient_Post(t *testing.T) {
 		require.Equal(t, "{}", resp.Body.String())
 	})
 }
+
+func Test_DefaultClient_SignBody(t *testing.T) {
+	methods := []string{http.MethodPost, http.MethodDelete, http.MethodPatch}
+	for _, method := range methods {
+		t.Run(method, func(t *testing.T) {
+			ts := mocks.NewDmarketServer(common.MustReturnStatusOK(method, "/signed"))
+			defer ts.Close()
+			apiClient, err := dmarket.NewClient(ts.URL(), ts.PublicKey, ts.PrivareKey)
+			require.NoError(t, err)
+			payload := `{"Offers":[{"AssetID":"asset","Price":{"Currency":"USD","Amount":1.5}}]}`
+			req, err := http.NewRequest(method, "/signed?q=1", strings.NewReader(payload))
+			require.NoError(t, err)
+			resp, err := apiClient.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.Equal(t, payload, resp.Body.String())
+		})
+	}
+}
+
+func Test_DefaultClient_Context(t *testing.T) {
+	ts := mocks.NewDmarketServer(common.MustDoLongResponse(http.MethodGet, "/long", 2))
+	defer ts.Close()
+	t.Run("error: client request timeout", func(t *testing.T) {
+		apiClient, err := dmarket.NewClient(ts.URL(), ts.PublicKey, ts.PrivareKey, dmarket.WithRequestTimeout(time.Second))
+		require.NoError(t, err)
+		_, err = apiClient.DefaultClient.Get("/long")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("success: context deadline takes precedence", func(t *testing.T) {
+		apiClient, err := dmarket.NewClient(ts.URL(), ts.PublicKey, ts.PrivareKey, dmarket.WithRequestTimeout(time.Second))
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+		defer cancel()
+		resp, err := apiClient.DefaultClient.GetContext(ctx, "/long")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+	t.Run("error: cancelled context", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(common.MustDoLongResponse(http.MethodPost, "/marketplace-api/v1/user-offers/create", 2))
+		defer ts.Close()
+		apiClient, err := dmarket.NewClient(ts.URL(), ts.PublicKey, ts.PrivareKey)
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+		_, err = apiClient.Exchange.Offers.Create(ctx, dmarket.CreateOffer{AssetID: "asset"})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+type recordTransport struct {
+	requests int
+}
+
+func (r *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func Test_DefaultClient_Options(t *testing.T) {
+	ts := mocks.NewDmarketServer(common.NewEndpointBehavior(http.MethodGet, "/headers", func(context *gin.Context) {
+		context.String(http.StatusOK, context.GetHeader("User-Agent")+" "+context.GetHeader("X-Sign-Date"))
+	}))
+	defer ts.Close()
+	transport := &recordTransport{}
+	now := time.Now().Add(-time.Minute).Truncate(time.Second)
+	apiClient, err := dmarket.NewClient(ts.URL(), ts.PublicKey, ts.PrivareKey,
+		dmarket.WithHTTPClient(&http.Client{Transport: transport}),
+		dmarket.WithUserAgent("dmarket-go-test/1.0"),
+		dmarket.WithClock(func() time.Time { return now }),
+		dmarket.WithRateLimit(rate.Inf, 1),
+	)
+	require.NoError(t, err)
+	resp, err := apiClient.DefaultClient.Get("/headers")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "dmarket-go-test/1.0 "+strconv.FormatInt(now.Unix(), 10), resp.Body.String())
+	require.Equal(t, 1, transport.requests)
+}
+
+func Test_DefaultClient_GroupRateLimits(t *testing.T) {
+	ts := mocks.NewDmarketServer(
+		common.MustReturnStatusOK(http.MethodGet, "/exchange/v1/market/items"),
+		common.MustReturnStatusOK(http.MethodPost, "/marketplace-api/v1/user-offers/create"),
+	)
+	defer ts.Close()
+	ts.SetGroupRateLimit(dmarket.GroupMarket, rate.Every(time.Minute), 1)
+	apiClient, err := dmarket.NewClient(ts.URL(), ts.PublicKey, ts.PrivareKey,
+		dmarket.WithRetryPolicy(dmarket.RetryPolicy{MaxAttempts: 1}))
+	require.NoError(t, err)
+	resp, err := apiClient.DefaultClient.Get("/exchange/v1/market/items")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, err = apiClient.DefaultClient.Get("/exchange/v1/market/items")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	// the saturated market group does not block trading requests
+	resp, err = apiClient.DefaultClient.Post("/marketplace-api/v1/user-offers/create", strings.NewReader(`{"Offers":[]}`))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func Test_DefaultClient_Retry(t *testing.T) {
+	policy := dmarket.WithRetryPolicy(dmarket.RetryPolicy{MaxAttempts: 3, BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond})
+	errTests := []struct {
+		name     string
+		method   string
+		errCode  int
+		attempts int
+	}{
+		{name: "GET 429 is retried", method: http.MethodGet, errCode: http.StatusTooManyRequests, attempts: 3},
+		{name: "GET 503 is retried", method: http.MethodGet, errCode: http.StatusServiceUnavailable, attempts: 3},
+		{name: "GET 404 is not retried", method: http.MethodGet, errCode: http.StatusNotFound, attempts: 1},
+		{name: "POST 429 is retried", method: http.MethodPost, errCode: http.StatusTooManyRequests, attempts: 3},
+		{name: "POST 500 is not retried", method: http.MethodPost, errCode: http.StatusInternalServerError, attempts: 1},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := mocks.NewDmarketServer(common.MustReturnHTTPError(tt.method, "/retry", tt.errCode))
+			defer ts.Close()
+			apiClient, err := dmarket.NewClient(ts.URL(), ts.PublicKey, ts.PrivareKey, policy)
+			require.NoError(t, err)
+			var resp dmarket.Response
+			if tt.method == http.MethodGet {
+				resp, err = apiClient.DefaultClient.Get("/retry")
+			} else {
+				resp, err = apiClient.DefaultClient.Post("/retry", strings.NewReader(`{"retry":true}`))
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.errCode, resp.StatusCode)
+			require.Equal(t, tt.attempts, resp.Attempts)
+		})
+	}
+	t.Run("success after retries", func(t *testing.T) {
+		var calls int
+		ts := mocks.NewDmarketServer(common.NewEndpointBehavior(http.MethodPost, "/retry", func(context *gin.Context) {
+			calls++
+			if calls < 3 {
+				context.Header("Retry-After", "0")
+				common.WriteError(context, http.StatusTooManyRequests, "", "too many requests")
+				return
+			}
+			body, _ := io.ReadAll(context.Request.Body)
+			context.String(http.StatusOK, string(body))
+		}))
+		defer ts.Close()
+		apiClient, err := dmarket.NewClient(ts.URL(), ts.PublicKey, ts.PrivareKey, policy)
+		require.NoError(t, err)
+		resp, err := apiClient.DefaultClient.Post("/retry", strings.NewReader(`{"retry":true}`))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, 3, resp.Attempts)
+		// the body is resent and signed on every attempt
+		require.Equal(t, `{"retry":true}`, resp.Body.String())
+	})
+	t.Run("long Retry-After is capped", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(common.NewEndpointBehavior(http.MethodGet, "/retry", func(context *gin.Context) {
+			context.Header("Retry-After", "120")
+			common.WriteError(context, http.StatusTooManyRequests, "", "too many requests")
+		}))
+		defer ts.Close()
+		apiClient, err := dmarket.NewClient(ts.URL(), ts.PublicKey, ts.PrivareKey, policy)
+		require.NoError(t, err)
+		start := time.Now()
+		resp, err := apiClient.DefaultClient.Get("/retry")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		require.Equal(t, 3, resp.Attempts)
+		require.Less(t, time.Since(start), time.Second)
+	})
+	t.Run("Retry-After exceeds the context deadline", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(common.NewEndpointBehavior(http.MethodGet, "/retry", func(context *gin.Context) {
+			context.Header("Retry-After", "60")
+			common.WriteError(context, http.StatusTooManyRequests, "", "too many requests")
+		}))
+		defer ts.Close()
+		apiClient, err := dmarket.NewClient(ts.URL(), ts.PublicKey, ts.PrivareKey,
+			dmarket.WithRetryPolicy(dmarket.RetryPolicy{MaxAttempts: 3, BaseDelay: 10 * time.Millisecond, MaxDelay: time.Minute}))
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		start := time.Now()
+		resp, err := apiClient.DefaultClient.GetContext(ctx, "/retry")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		require.Equal(t, 1, resp.Attempts)
+		// the client does not wait for the deadline that comes before the next attempt
+		require.Less(t, time.Since(start), time.Second)
+	})
+	t.Run("context expires while waiting", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(common.NewEndpointBehavior(http.MethodGet, "/retry", func(context *gin.Context) {
+			common.WriteError(context, http.StatusServiceUnavailable, "", "unavailable")
+		}))
+		defer ts.Close()
+		apiClient, err := dmarket.NewClient(ts.URL(), ts.PublicKey, ts.PrivareKey,
+			dmarket.WithRetryPolicy(dmarket.RetryPolicy{MaxAttempts: 3, BaseDelay: 400 * time.Millisecond, MaxDelay: time.Second}))
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+		resp, err := apiClient.DefaultClient.GetContext(ctx, "/retry")
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		require.Equal(t, 1, resp.Attempts)
+	})
+}
+
+func Test_DefaultClient_APIErrors(t *testing.T) {
+	ts := mocks.NewDmarketServer(common.MustReturnStatusOK(http.MethodGet, "/account/v1/balance"))
+	defer ts.Close()
+	t.Run("invalid signature", func(t *testing.T) {
+		other := mocks.NewDmarketServer()
+		defer other.Close()
+		apiClient, err := dmarket.NewClient(ts.URL(), ts.PublicKey, other.PrivareKey)
+		require.NoError(t, err)
+		_, err = apiClient.Account.Balance(context.Background())
+		require.ErrorIs(t, err, dmarket.ErrInvalidSignature)
+	})
+	t.Run("not found", func(t *testing.T) {
+		apiClient, err := dmarket.NewClient(ts.URL(), ts.PublicKey, ts.PrivareKey)
+		require.NoError(t, err)
+		_, err = apiClient.Account.User(context.Background())
+		require.ErrorIs(t, err, dmarket.ErrItemNotFound)
+		var runtime dmarket.RuntimeError
+		require.ErrorAs(t, err, &runtime)
+		require.Contains(t, runtime.Message, "/account/v1/user")
+	})
+}
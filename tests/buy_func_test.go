@@ -0,0 +1,83 @@
+package tests_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks"
+	"github.com/defernest/dmarket-go/mocks/buy"
+	"github.com/defernest/dmarket-go/mocks/common"
+	"github.com/defernest/dmarket-go/mocks/items"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExchange_Buy(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(buy.MustReturnSuccess(map[string]int64{"1": 100, "2": 200}))
+		defer ts.Close()
+		e := dmarket.NewExchange(ts.Client)
+		objects := []dmarket.Object{
+			{ItemID: "a", Price: dmarket.Price{Usd: 100}, Extra: dmarket.Extra{OfferID: "1"}},
+			{ItemID: "b", Price: dmarket.Price{Usd: 150}, Extra: dmarket.Extra{OfferID: "2"}},
+			{ItemID: "c", Price: dmarket.Price{Usd: 300}, Extra: dmarket.Extra{OfferID: "3"}},
+		}
+		resp, err := e.Buy(context.Background(), objects, 1000)
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.OrderID)
+		require.Len(t, resp.Outcomes, 3)
+		require.Equal(t, dmarket.BuyStatusBought, resp.Outcomes[0].Status)
+		require.Equal(t, dmarket.BuyStatusPriceChanged, resp.Outcomes[1].Status)
+		require.Equal(t, dmarket.BuyStatusAlreadySold, resp.Outcomes[2].Status)
+
+		resp, err = e.Buy(context.Background(), objects[:1], 1000)
+		require.NoError(t, err)
+		require.Equal(t, dmarket.BuyStatusAlreadySold, resp.Outcomes[0].Status)
+	})
+	t.Run("listed objects", func(t *testing.T) {
+		market := mocks.NewDmarketServer(items.MustReturnSuccess(20))
+		defer market.Close()
+		pager, err := dmarket.NewExchange(market.Client).Items.PagerFromDmarket()
+		require.NoError(t, err)
+		require.True(t, pager.Next(context.Background()), pager.Err())
+		objects := pager.Page().Objects
+		require.NotEmpty(t, objects)
+		offers := make(map[string]int64, len(objects))
+		var total dmarket.Cents
+		for _, object := range objects {
+			offers[object.Extra.OfferID] = int64(object.Price.Usd)
+			total += object.Price.Usd
+		}
+
+		ts := mocks.NewDmarketServer(buy.MustReturnSuccess(offers))
+		defer ts.Close()
+		resp, err := dmarket.NewExchange(ts.Client).Buy(context.Background(), objects, total)
+		require.NoError(t, err)
+		require.Len(t, resp.Outcomes, len(objects))
+		for _, outcome := range resp.Outcomes {
+			require.Equal(t, dmarket.BuyStatusBought, outcome.Status)
+		}
+	})
+	t.Run("error: http error", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(common.MustReturnHTTPError(http.MethodPatch, "/exchange/v1/offers-buy", http.StatusBadRequest))
+		defer ts.Close()
+		_, err := dmarket.NewExchange(ts.Client).Buy(context.Background(), []dmarket.Object{
+			{ItemID: "a", Price: dmarket.Price{Usd: 100}, Extra: dmarket.Extra{OfferID: "1"}},
+		}, 100)
+		require.ErrorAs(t, err, &dmarket.ErrorRepresentation{})
+	})
+	t.Run("error: insufficient funds", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(buy.MustReturnSuccess(map[string]int64{"1": 100}).WithBalance(50))
+		defer ts.Close()
+		_, err := dmarket.NewExchange(ts.Client).Buy(context.Background(), []dmarket.Object{
+			{ItemID: "a", Price: dmarket.Price{Usd: 100}, Extra: dmarket.Extra{OfferID: "1"}},
+		}, 100)
+		require.ErrorIs(t, err, dmarket.ErrInsufficientFunds)
+		var representation dmarket.ErrorRepresentation
+		require.ErrorAs(t, err, &representation)
+		require.Equal(t, http.StatusBadRequest, representation.Response.StatusCode)
+		require.Equal(t, "InsufficientFunds", representation.Runtime.Err)
+	})
+}
@@ -0,0 +1,35 @@
+package tests_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks"
+	"github.com/defernest/dmarket-go/mocks/common"
+	"github.com/defernest/dmarket-go/mocks/games"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExchange_Games(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(games.MustReturnSuccess())
+		defer ts.Close()
+		list, err := dmarket.NewExchange(ts.Client).Games(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, games.Catalog(), list)
+		ids := make([]dmarket.Game, 0, len(list))
+		for _, game := range list {
+			ids = append(ids, game.ID)
+		}
+		require.ElementsMatch(t, dmarket.Games(), ids)
+	})
+	t.Run("error: http error", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(common.MustReturnHTTPError(http.MethodGet, "/game/v1/games", http.StatusServiceUnavailable))
+		defer ts.Close()
+		_, err := dmarket.NewExchange(ts.Client).Games(context.Background())
+		require.ErrorAs(t, err, &dmarket.ErrorRepresentation{})
+	})
+}
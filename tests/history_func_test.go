@@ -0,0 +1,73 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks"
+	"github.com/defernest/dmarket-go/mocks/history"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	start := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+	var trades []dmarket.ClosedTrade
+	for i := 0; i < 150; i++ {
+		price := dmarket.NewMoney(dmarket.Cents(100+i), dmarket.CurrencyUSD)
+		sale := dmarket.ClosedTrade{
+			Kind:      dmarket.TradeSale,
+			ID:        "offer-" + strconv.Itoa(i),
+			Title:     "title",
+			Price:     price,
+			Fee:       price.Fraction(0.05),
+			Status:    "OfferStatusSold",
+			CreatedAt: start.Add(time.Duration(i) * time.Hour),
+			ClosedAt:  start.Add(time.Duration(i)*time.Hour + time.Minute),
+		}
+		sale.Net = dmarket.NewMoney(sale.Price.Amount-sale.Fee.Amount, dmarket.CurrencyUSD)
+		trades = append(trades, sale)
+	}
+	purchase := dmarket.ClosedTrade{
+		Kind:      dmarket.TradePurchase,
+		ID:        "target",
+		Title:     "title",
+		Price:     dmarket.NewMoney(90, dmarket.CurrencyUSD),
+		Fee:       dmarket.NewMoney(0, dmarket.CurrencyUSD),
+		Net:       dmarket.NewMoney(90, dmarket.CurrencyUSD),
+		CreatedAt: start,
+		ClosedAt:  start.Add(30 * time.Minute),
+	}
+	mock := history.MustReturnSuccess(append(trades, purchase)...)
+	ts := mocks.NewDmarketServer(mock.ClosedOffers(), mock.ClosedTargets())
+	defer ts.Close()
+	h := dmarket.NewHistory(ts.Client)
+	params := dmarket.HistoryParams{Game: dmarket.GameCSGO, From: start, To: start.Add(120 * time.Hour), Limit: 50}
+
+	t.Run("closed offers in range", func(t *testing.T) {
+		got, err := h.ClosedOffers(context.Background(), params)
+		require.NoError(t, err)
+		require.Equal(t, trades[:120], got)
+	})
+	t.Run("trades sorted by closed time", func(t *testing.T) {
+		got, err := h.Trades(context.Background(), params)
+		require.NoError(t, err)
+		require.Len(t, got, 121)
+		require.Equal(t, trades[0], got[0])
+		require.Equal(t, purchase, got[1])
+		require.Equal(t, trades[1], got[2])
+
+		var buf bytes.Buffer
+		require.NoError(t, dmarket.WriteTradesCSV(&buf, got))
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 122)
+		require.Equal(t, []string{"purchase", "target", "", "title", "USD", "0.90", "0.00", "0.90", "",
+			"2021-09-01T00:00:00Z", "2021-09-01T00:30:00Z"}, records[2])
+	})
+}
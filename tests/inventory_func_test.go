@@ -0,0 +1,101 @@
+package tests_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks"
+	"github.com/defernest/dmarket-go/mocks/inventory"
+	"github.com/defernest/dmarket-go/mocks/items"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventory_Transfers(t *testing.T) {
+	mock := inventory.MustReturnSuccess(100 * time.Millisecond)
+	mock.Fail("broken")
+	ts := mocks.NewDmarketServer(mock.Deposit(), mock.Withdraw(), mock.Status())
+	defer ts.Close()
+	i := dmarket.NewInventory(ts.Client)
+	i.PollInterval = 50 * time.Millisecond
+	skin := dmarket.Object{ItemID: "skin", Extra: dmarket.Extra{LinkID: "link"}}
+
+	t.Run("deposit", func(t *testing.T) {
+		transfer, err := i.Deposit(context.Background(), skin)
+		require.NoError(t, err)
+		require.Equal(t, dmarket.TransferStatusPending, transfer.Status)
+		require.Equal(t, []dmarket.TransferAsset{{AssetID: "skin", LinkID: "link"}}, transfer.Assets)
+		done, err := i.WaitForTransfer(context.Background(), transfer.TransferID)
+		require.NoError(t, err)
+		require.Equal(t, dmarket.TransferStatusSuccess, done.Status)
+	})
+	t.Run("withdraw failed", func(t *testing.T) {
+		transfer, err := i.Withdraw(context.Background(), skin, dmarket.Object{ItemID: "broken"})
+		require.NoError(t, err)
+		require.Equal(t, dmarket.TransferWithdraw, transfer.Kind)
+		done, err := i.WaitForTransfer(context.Background(), transfer.TransferID)
+		require.ErrorIs(t, err, dmarket.ErrTransferFailed)
+		require.Equal(t, dmarket.TransferStatusFailed, done.Status)
+	})
+	t.Run("unknown transfer", func(t *testing.T) {
+		_, err := i.WaitForTransfer(context.Background(), "unknown")
+		require.ErrorIs(t, err, dmarket.ErrItemNotFound)
+	})
+}
+
+func TestInventory_SyncAndReload(t *testing.T) {
+	before := items.Catalog(30, 1000)
+	after := items.Evolve(before, 10, 5, 20)
+	mock := inventory.MustReturnSync(before, after, 2)
+	ts := mocks.NewDmarketServer(mock.Trigger(), mock.Status(), mock.Items())
+	defer ts.Close()
+	i := dmarket.NewInventory(ts.Client)
+	i.SyncPolicy = dmarket.RetryPolicy{MaxAttempts: 5, BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+
+	pager, err := dmarket.NewExchange(ts.Client).Items.PagerFromUserInventory(dmarket.ItemsLimitPerRequest(7))
+	require.NoError(t, err)
+	stale, err := pager.CollectAll(context.Background(), 0)
+	require.NoError(t, err)
+	require.Equal(t, before, stale)
+
+	objects, err := i.SyncAndReload(context.Background(), dmarket.GameCSGO, dmarket.ItemsLimitPerRequest(7))
+	require.NoError(t, err)
+	require.Equal(t, after, objects)
+
+	mock.Fail("private inventory")
+	_, err = i.SyncAndReload(context.Background(), dmarket.GameCSGO)
+	require.ErrorIs(t, err, dmarket.ErrSyncFailed)
+}
+
+func TestInventory_SyncAndReloadStale(t *testing.T) {
+	before := items.Catalog(30, 1000)
+	after := items.Evolve(before, 10, 5, 20)
+	mock := inventory.MustReturnSync(before, after, 1)
+	mock.Stale(2)
+	ts := mocks.NewDmarketServer(mock.Trigger(), mock.Status(), mock.Items())
+	defer ts.Close()
+	i := dmarket.NewInventory(ts.Client)
+	i.SyncPolicy = dmarket.RetryPolicy{MaxAttempts: 5, BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+
+	objects, err := i.SyncAndReload(context.Background(), dmarket.GameCSGO)
+	require.NoError(t, err)
+	require.Equal(t, after, objects)
+}
+
+func TestInventory_SyncAndReloadWithoutUpdatedAt(t *testing.T) {
+	before := items.Catalog(30, 1000)
+	after := items.Evolve(before, 10, 5, 20)
+	mock := inventory.MustReturnSync(before, after, 1)
+	mock.Stale(2)
+	mock.WithoutUpdatedAt()
+	ts := mocks.NewDmarketServer(mock.Trigger(), mock.Status(), mock.Items())
+	defer ts.Close()
+	i := dmarket.NewInventory(ts.Client)
+	i.SyncPolicy = dmarket.RetryPolicy{MaxAttempts: 5, BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+
+	objects, err := i.SyncAndReload(context.Background(), dmarket.GameCSGO)
+	require.NoError(t, err)
+	require.Equal(t, after, objects)
+}
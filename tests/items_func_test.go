@@ -2,16 +2,18 @@ package tests_test
 
 import (
 	"context"
+	"fmt"
 	"github.com/defernest/dmarket-go/dmarket"
 	"github.com/defernest/dmarket-go/mocks"
 	"github.com/defernest/dmarket-go/mocks/common"
 	"github.com/defernest/dmarket-go/mocks/items"
+	"github.com/hashicorp/go-multierror"
 	"net/http"
-	"strconv"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,17 +22,15 @@ func TestGetAllItems(t *testing.T) {
 		itemscount := 1000
 		ts := mocks.NewDmarketServer(items.MustReturnSuccess(itemscount))
 		wg := sync.WaitGroup{}
-		results := dmarket.NewExchange(ts.Client).Items.GetAllItemsFromDmarket(context.Background())
+		results, err := dmarket.NewExchange(ts.Client).Items.GetAllItemsFromDmarket(context.Background())
+		require.NoError(t, err)
 		var objects []dmarket.Object
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			for {
-				r := <-results
+			for r := range results {
 				require.NoError(t, r.Error)
-				if len(r.Objects) == 0 {
-					return
-				}
+				require.NotEmpty(t, r.Objects)
 				objects = append(objects, r.Objects...)
 			}
 		}()
@@ -41,7 +41,8 @@ func TestGetAllItems(t *testing.T) {
 		ts := mocks.NewDmarketServer(items.MustReturnSuccess(5000))
 		wg := sync.WaitGroup{}
 		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-		results := dmarket.NewExchange(ts.Client).Items.GetAllItemsFromDmarket(ctx)
+		results, err := dmarket.NewExchange(ts.Client).Items.GetAllItemsFromDmarket(ctx)
+		require.NoError(t, err)
 		deadline, _ := ctx.Deadline()
 		var objects []dmarket.Object
 		require.Eventually(t, func() bool {
@@ -63,19 +64,199 @@ func TestGetAllItems(t *testing.T) {
 		cancel()
 	})
 }
+func TestGetAllItems_Options(t *testing.T) {
+	ts := mocks.NewDmarketServer(items.MustReturnSuccess(10))
+	defer ts.Close()
+	results, err := dmarket.NewExchange(ts.Client).Items.GetAllItemsFromDmarket(context.Background(),
+		dmarket.ItemsPriceRange(10, 1),
+		dmarket.ItemsLimitPerRequest(0),
+		dmarket.ItemsGame(dmarket.GameTF2),
+		dmarket.ItemsCurrency("EUR"),
+	)
+	require.Nil(t, results)
+	require.ErrorIs(t, err, dmarket.ErrIncorrectPriceRange)
+	require.ErrorIs(t, err, dmarket.ErrLimitPerRequest)
+	require.ErrorIs(t, err, dmarket.ErrIncorrectCurrency)
+	var errs *multierror.Error
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs.Errors, 3)
+
+	_, err = dmarket.NewExchange(ts.Client).Items.GetAllItemsFromUserInventory(context.Background(), dmarket.ItemsTypes())
+	require.ErrorIs(t, err, dmarket.ErrIncorrectOfferType)
+}
+
+func TestItems_Game(t *testing.T) {
+	ts := mocks.NewDmarketServer(items.MustReturnSuccess(150))
+	defer ts.Close()
+	results, err := dmarket.NewExchange(ts.Client).Items.GetAllItemsFromDmarket(context.Background(), dmarket.ItemsGame(dmarket.GameCSGO))
+	require.NoError(t, err)
+	var objects []dmarket.Object
+	for r := range results {
+		require.NoError(t, r.Error)
+		objects = append(objects, r.Objects...)
+	}
+	require.Len(t, objects, 150)
+	for _, object := range objects {
+		require.Equal(t, string(dmarket.GameCSGO), object.GameID)
+		require.Empty(t, object.Extra.Hero)
+	}
+}
+
+func TestItems_Currency(t *testing.T) {
+	ts := mocks.NewDmarketServer(items.MustReturnSuccess(50))
+	defer ts.Close()
+	results, err := dmarket.NewExchange(ts.Client).Items.GetAllItemsFromDmarket(context.Background(),
+		dmarket.ItemsCurrency(dmarket.CurrencyDMC), dmarket.ItemsPriceRange(100, 200))
+	require.NoError(t, err)
+	var objects []dmarket.Object
+	for r := range results {
+		require.NoError(t, r.Error)
+		objects = append(objects, r.Objects...)
+	}
+	require.Len(t, objects, 50)
+	for _, object := range objects {
+		price := object.Price.In(dmarket.CurrencyDMC)
+		require.Equal(t, dmarket.CurrencyDMC, price.Currency)
+		require.GreaterOrEqual(t, price.Amount, dmarket.Cents(100))
+		require.LessOrEqual(t, price.Amount, dmarket.Cents(200))
+	}
+}
+
+func TestItems_Filters(t *testing.T) {
+	ts := mocks.NewDmarketServer(items.MustReturnSuccess(250))
+	defer ts.Close()
+	results, err := dmarket.NewExchange(ts.Client).Items.GetAllItemsFromDmarket(context.Background(),
+		dmarket.ItemsGame(dmarket.GameCSGO),
+		dmarket.ItemsOrder(dmarket.OrderByPrice, dmarket.OrderAsc),
+		dmarket.ItemsTreeFilters(dmarket.TreeFilters{Exterior: []string{"factory new", "minimal wear"}, FloatTo: 0.15}),
+		dmarket.ItemsTypes(dmarket.OfferTypeDmarket),
+		dmarket.ItemsExactTitle("AK-47 | Redline"),
+	)
+	require.NoError(t, err)
+	var count int
+	for r := range results {
+		require.NoError(t, r.Error)
+		count += len(r.Objects)
+		for i, object := range r.Objects {
+			require.Equal(t, "AK-47 | Redline", object.Title)
+			require.Contains(t, []string{"factory new", "minimal wear"}, object.Extra.Exterior)
+			require.Equal(t, string(dmarket.OfferTypeDmarket), object.Type)
+			if i > 0 {
+				require.LessOrEqual(t, r.Objects[i-1].Price.Usd, object.Price.Usd)
+			}
+		}
+	}
+	require.Equal(t, 250, count)
+}
+
+func TestGetAllItems_Concurrent(t *testing.T) {
+	ts := mocks.NewDmarketServer(items.MustReturnSuccess(250))
+	defer ts.Close()
+	e := dmarket.NewExchange(ts.Client)
+	wg := sync.WaitGroup{}
+	for n := 1; n <= 8; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			title := fmt.Sprintf("title %d", n)
+			results, err := e.Items.GetAllItemsFromDmarket(context.Background(),
+				dmarket.ItemsTitle(title),
+				dmarket.ItemsLimitPerRequest(10*n),
+			)
+			if !assert.NoError(t, err) {
+				return
+			}
+			count := 0
+			for r := range results {
+				if !assert.NoError(t, r.Error) {
+					break
+				}
+				assert.LessOrEqual(t, len(r.Objects), 10*n)
+				for _, object := range r.Objects {
+					assert.Equal(t, title, object.Title)
+				}
+				count += len(r.Objects)
+			}
+			assert.Equal(t, 250, count)
+		}(n)
+	}
+	wg.Wait()
+}
+
+func TestItemsPager(t *testing.T) {
+	t.Run("success: pages are over", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(items.MustReturnSuccess(250))
+		defer ts.Close()
+		pager, err := dmarket.NewExchange(ts.Client).Items.PagerFromDmarket(dmarket.ItemsLimitPerRequest(100))
+		require.NoError(t, err)
+		var sizes []int
+		for pager.Next(context.Background()) {
+			sizes = append(sizes, len(pager.Page().Objects))
+		}
+		require.NoError(t, pager.Err())
+		require.Equal(t, []int{100, 100, 50}, sizes)
+		require.False(t, pager.Next(context.Background()))
+	})
+	t.Run("success: collect all with max", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(items.MustReturnSuccess(250))
+		defer ts.Close()
+		e := dmarket.NewExchange(ts.Client)
+		pager, err := e.Items.PagerFromDmarket(dmarket.ItemsLimitPerRequest(100))
+		require.NoError(t, err)
+		objects, err := pager.CollectAll(context.Background(), 120)
+		require.NoError(t, err)
+		require.Len(t, objects, 120)
+		require.False(t, pager.Next(context.Background()))
+
+		pager, err = e.Items.PagerFromDmarket(dmarket.ItemsLimitPerRequest(100))
+		require.NoError(t, err)
+		objects, err = pager.CollectAll(context.Background(), 0)
+		require.NoError(t, err)
+		require.Len(t, objects, 250)
+	})
+	t.Run("success: no items", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(items.MustReturnSuccess(0))
+		defer ts.Close()
+		pager, err := dmarket.NewExchange(ts.Client).Items.PagerFromDmarket()
+		require.NoError(t, err)
+		require.False(t, pager.Next(context.Background()))
+		require.NoError(t, pager.Err())
+		require.Nil(t, pager.Page())
+	})
+	t.Run("error: stops on first error", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(common.MustReturnHTTPError(http.MethodGet, "/exchange/v1/market/items", http.StatusBadRequest))
+		defer ts.Close()
+		pager, err := dmarket.NewExchange(ts.Client).Items.PagerFromDmarket()
+		require.NoError(t, err)
+		objects, err := pager.CollectAll(context.Background(), 0)
+		require.Empty(t, objects)
+		require.ErrorAs(t, err, &dmarket.ErrorRepresentation{})
+		require.False(t, pager.Next(context.Background()))
+		require.Equal(t, err, pager.Err())
+	})
+	t.Run("error: options", func(t *testing.T) {
+		_, err := dmarket.NewExchange(nil).Items.PagerFromUserInventory(dmarket.ItemsLimitPerRequest(0))
+		require.ErrorIs(t, err, dmarket.ErrLimitPerRequest)
+	})
+}
+
 func TestItems_GetItems(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		wantItems := 100
 		ts := mocks.NewDmarketServer(items.MustReturnSuccess(wantItems))
 		e := dmarket.NewExchange(ts.Client)
-		response := e.Items.GetItems("/exchange/v1/market/items?")
+		query, err := e.Items.Query()
+		require.NoError(t, err)
+		response := e.Items.GetItems(context.Background(), "/exchange/v1/market/items?", query)
 		require.NoError(t, response.Error)
 		require.Len(t, response.Objects, wantItems)
 	})
 	t.Run("error: unmarshal error", func(t *testing.T) {
 		ts := mocks.NewDmarketServer(common.MustReturnBadBody(http.MethodGet, "/exchange/v1/market/items"))
 		e := dmarket.NewExchange(ts.Client)
-		response := e.Items.GetItems("/exchange/v1/market/items?")
+		query, err := e.Items.Query()
+		require.NoError(t, err)
+		response := e.Items.GetItems(context.Background(), "/exchange/v1/market/items?", query)
 		require.ErrorIs(t, response.Error, dmarket.ErrUnmarshalAPIResponse)
 	})
 	errTests := []struct {
@@ -90,7 +271,7 @@ func TestItems_GetItems(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := mocks.NewDmarketServer(common.MustReturnHTTPError(http.MethodGet, "/exchange/v1/market/items", tt.errCode))
 			e := dmarket.NewExchange(ts.Client)
-			response := e.Items.GetItems("/exchange/v1/market/items?")
+			response := e.Items.GetItems(context.Background(), "/exchange/v1/market/items?", dmarket.ItemsQuery{})
 			require.ErrorAs(t, response.Error, &dmarket.ErrorRepresentation{})
 		})
 	}
@@ -132,8 +313,7 @@ func TestItems_GenerateItems(t *testing.T) {
 			for _, object := range is {
 				require.Equal(t, object.GameID, tc.params.GameId)
 				require.Equal(t, object.Title, tc.params.Title)
-				price, err := strconv.Atoi(object.Price.Usd)
-				require.NoError(t, err)
+				price := int(object.Price.Usd)
 				if tc.params.PriceFrom == 0 && tc.params.PriceTo == 0 {
 					require.Positive(t, price)
 				} else {
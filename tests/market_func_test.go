@@ -0,0 +1,40 @@
+package tests_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks"
+	"github.com/defernest/dmarket-go/mocks/market"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarket(t *testing.T) {
+	redline := dmarket.AggregatedPrice{Title: "AK-47 | Redline (Field-Tested)", BestAsk: 1234, AskCount: 12, BestBid: 1100, BidCount: 4}
+	mock := market.MustReturnSuccess(redline)
+	sales := []dmarket.Sale{
+		{Price: 1200, Date: time.Unix(1600000100, 0).UTC(), Type: dmarket.SaleOffer},
+		{Price: 1150, Date: time.Unix(1600000000, 0).UTC(), Type: dmarket.SaleTarget},
+	}
+	mock.SetSales(redline.Title, sales...)
+	ts := mocks.NewDmarketServer(mock.AggregatedPrices(), mock.LastSales())
+	defer ts.Close()
+	m := dmarket.NewMarket(ts.Client)
+
+	t.Run("aggregated prices", func(t *testing.T) {
+		prices, err := m.AggregatedPrices(context.Background(), dmarket.GameCSGO, redline.Title, "unknown")
+		require.NoError(t, err)
+		require.Equal(t, []dmarket.AggregatedPrice{redline, {Title: "unknown"}}, prices)
+	})
+	t.Run("last sales", func(t *testing.T) {
+		got, err := m.LastSales(context.Background(), dmarket.GameCSGO, redline.Title, 1)
+		require.NoError(t, err)
+		require.Equal(t, sales[:1], got)
+		got, err = m.LastSales(context.Background(), dmarket.GameCSGO, "unknown", 10)
+		require.NoError(t, err)
+		require.Empty(t, got)
+	})
+}
@@ -0,0 +1,79 @@
+package tests_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks"
+	"github.com/defernest/dmarket-go/mocks/common"
+	"github.com/defernest/dmarket-go/mocks/offers"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOffers(t *testing.T) {
+	market := offers.MustReturnSuccess("asset-1", "asset-2")
+	ts := mocks.NewDmarketServer(market.Create(), market.Edit(), market.Delete())
+	defer ts.Close()
+	e := dmarket.NewExchange(ts.Client)
+
+	created, err := e.Offers.Create(context.Background(),
+		dmarket.CreateOffer{AssetID: "asset-1", Price: dmarket.MarketplacePrice{Currency: "USD", Amount: 150}},
+		dmarket.CreateOffer{AssetID: "asset-2", Price: dmarket.MarketplacePrice{Currency: "USD", Amount: 0}},
+		dmarket.CreateOffer{AssetID: "asset-3", Price: dmarket.MarketplacePrice{Currency: "USD", Amount: 100}},
+	)
+	require.NoError(t, err)
+	require.Len(t, created.Result, 3)
+	require.True(t, created.Result[0].Successful)
+	require.False(t, created.Result[1].Successful)
+	require.Equal(t, "InvalidPrice", created.Result[1].Error.Code)
+	require.False(t, created.Result[2].Successful)
+	require.Equal(t, "AssetNotFound", created.Result[2].Error.Code)
+	require.Error(t, created.Err())
+	offer, ok := market.Offer(created.Result[0].OfferID)
+	require.True(t, ok)
+	require.Equal(t, int64(150), offer.Price.Amount)
+
+	edited, err := e.Offers.Edit(context.Background(), dmarket.EditOffer{
+		OfferID: created.Result[0].OfferID,
+		AssetID: "asset-1",
+		Price:   dmarket.MarketplacePrice{Currency: "USD", Amount: 149},
+	})
+	require.NoError(t, err)
+	require.NoError(t, edited.Err())
+	offer, ok = market.Offer(edited.Result[0].NewOfferID)
+	require.True(t, ok)
+	require.Equal(t, int64(149), offer.Price.Amount)
+
+	deleted, err := e.Offers.Delete(context.Background(),
+		dmarket.DeleteOffer{OfferID: edited.Result[0].NewOfferID, AssetID: "asset-1"},
+		dmarket.DeleteOffer{OfferID: created.Result[0].OfferID, AssetID: "asset-1"},
+	)
+	require.NoError(t, err)
+	require.True(t, deleted.Result[0].Successful)
+	require.False(t, deleted.Result[1].Successful)
+	require.Equal(t, "OfferNotFound", deleted.Result[1].Error.Code)
+	require.Zero(t, market.Len())
+}
+
+func TestOffers_Errors(t *testing.T) {
+	t.Run("error: empty batch", func(t *testing.T) {
+		e := dmarket.NewExchange(nil)
+		_, err := e.Offers.Create(context.Background())
+		require.ErrorIs(t, err, dmarket.ErrEmptyBatch)
+	})
+	t.Run("error: http error", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(common.MustReturnHTTPError(http.MethodPost, "/marketplace-api/v1/user-offers/create", http.StatusBadRequest))
+		defer ts.Close()
+		_, err := dmarket.NewExchange(ts.Client).Offers.Create(context.Background(), dmarket.CreateOffer{AssetID: "asset"})
+		require.ErrorAs(t, err, &dmarket.ErrorRepresentation{})
+	})
+	t.Run("error: unmarshal error", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(common.MustReturnBadBody(http.MethodPost, "/marketplace-api/v1/user-offers/edit"))
+		defer ts.Close()
+		_, err := dmarket.NewExchange(ts.Client).Offers.Edit(context.Background(), dmarket.EditOffer{OfferID: "offer"})
+		require.ErrorIs(t, err, dmarket.ErrUnmarshalAPIResponse)
+	})
+}
@@ -0,0 +1,102 @@
+package tests_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks"
+	"github.com/defernest/dmarket-go/mocks/items"
+	"github.com/defernest/dmarket-go/mocks/offers"
+	"github.com/defernest/dmarket-go/repricer"
+
+	"github.com/stretchr/testify/require"
+)
+
+func listing(assetID, offerID, title string, price dmarket.Cents) dmarket.Object {
+	object := dmarket.Object{ItemID: assetID, Title: title, Price: dmarket.Price{Usd: price}}
+	object.Extra.OfferID = offerID
+	return object
+}
+
+func TestRepricer_Run(t *testing.T) {
+	market := offers.MustReturnSuccess("a1", "a2", "a3")
+	ts := mocks.NewDmarketServer(market.Create())
+	placed, err := dmarket.NewExchange(ts.Client).Offers.Create(context.Background(),
+		dmarket.CreateOffer{AssetID: "a1", Price: dmarket.MarketplacePrice{Currency: "USD", Amount: 200}},
+		dmarket.CreateOffer{AssetID: "a2", Price: dmarket.MarketplacePrice{Currency: "USD", Amount: 500}},
+		dmarket.CreateOffer{AssetID: "a3", Price: dmarket.MarketplacePrice{Currency: "USD", Amount: 300}},
+	)
+	ts.Close()
+	require.NoError(t, err)
+	require.NoError(t, placed.Err())
+	ak, awp, m4 := placed.Result[0].OfferID, placed.Result[1].OfferID, placed.Result[2].OfferID
+
+	inventory := []dmarket.Object{
+		listing("a1", ak, "AK-47", 200),
+		listing("a2", awp, "AWP", 500),
+		listing("a3", m4, "M4A4", 300),
+		listing("a4", "", "AK-47", 0),
+		listing("a5", "gone", "AWP", 600),
+	}
+	// the mock keeps the catalog order, so the own offers and the similar titles are ranked first
+	catalog := []dmarket.Object{
+		inventory[0], inventory[1], inventory[2], inventory[4],
+		listing("c1", "redline", "AK-47 Redline", 100),
+		listing("c2", "cheap", "AK-47", 150),
+		listing("c3", "awp", "AWP", 480),
+		listing("c4", "other", "AK-47", 180),
+	}
+	ts = mocks.NewDmarketServer(
+		market.Edit(),
+		items.MustReturnInventory(func() []dmarket.Object { return inventory }),
+		items.MustReturnCatalog(catalog),
+	)
+	defer ts.Close()
+	config := repricer.Config{
+		Game:      dmarket.GameCSGO,
+		Strategy:  repricer.FloorCeiling(repricer.Undercut(1), 160, 0),
+		BatchSize: 2,
+		Depth:     1,
+		DryRun:    true,
+	}
+	planned := []repricer.Change{
+		{OfferID: ak, AssetID: "a1", Title: "AK-47", OldPrice: 200, NewPrice: 160},
+		{OfferID: awp, AssetID: "a2", Title: "AWP", OldPrice: 500, NewPrice: 479},
+		{OfferID: "gone", AssetID: "a5", Title: "AWP", OldPrice: 600, NewPrice: 479},
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		r, err := repricer.New(ts.Client, config)
+		require.NoError(t, err)
+		report, err := r.Run(context.Background())
+		require.NoError(t, err)
+		require.True(t, report.DryRun)
+		require.Equal(t, planned, report.Changes)
+		require.Equal(t, 1, report.Kept)
+		require.NoError(t, report.Err())
+		offer, ok := market.Offer(ak)
+		require.True(t, ok)
+		require.Equal(t, int64(200), offer.Price.Amount)
+	})
+	t.Run("edit", func(t *testing.T) {
+		config.DryRun = false
+		r, err := repricer.New(ts.Client, config)
+		require.NoError(t, err)
+		report, err := r.Run(context.Background())
+		require.NoError(t, err)
+		require.Len(t, report.Changes, 3)
+		for i, change := range report.Changes[:2] {
+			require.NoError(t, change.Error)
+			offer, ok := market.Offer(change.NewOfferID)
+			require.True(t, ok)
+			require.Equal(t, int64(planned[i].NewPrice), offer.Price.Amount)
+		}
+		_, ok := market.Offer(ak)
+		require.False(t, ok)
+		var failure dmarket.MarketplaceError
+		require.ErrorAs(t, report.Changes[2].Error, &failure)
+		require.Equal(t, "OfferNotFound", failure.Code)
+		require.ErrorAs(t, report.Err(), &failure)
+	})
+}
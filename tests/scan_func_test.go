@@ -0,0 +1,60 @@
+package tests_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks"
+	"github.com/defernest/dmarket-go/mocks/common"
+	"github.com/defernest/dmarket-go/mocks/items"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestItems_Scan(t *testing.T) {
+	t.Run("success: all catalog items once", func(t *testing.T) {
+		catalog := items.Catalog(3000, 100000)
+		ts := mocks.NewDmarketServer(items.MustReturnCatalog(catalog))
+		defer ts.Close()
+		objects, err := dmarket.NewExchange(ts.Client).Items.Scan(context.Background(),
+			dmarket.ScanParams{PriceTo: 100000, Bands: 4, Workers: 4, MaxBandTotal: 500},
+			dmarket.ItemsLimitPerRequest(100),
+		)
+		require.NoError(t, err)
+		require.Len(t, objects, len(catalog))
+		ids := make(map[string]bool, len(objects))
+		for _, object := range objects {
+			require.False(t, ids[object.ItemID], "duplicate item %s", object.ItemID)
+			ids[object.ItemID] = true
+		}
+		for _, object := range catalog {
+			require.True(t, ids[object.ItemID], "missed item %s", object.ItemID)
+		}
+	})
+	t.Run("success: price range", func(t *testing.T) {
+		catalog := items.Catalog(1000, 10000)
+		ts := mocks.NewDmarketServer(items.MustReturnCatalog(catalog))
+		defer ts.Close()
+		objects, err := dmarket.NewExchange(ts.Client).Items.Scan(context.Background(),
+			dmarket.ScanParams{PriceFrom: 1000, PriceTo: 5000, MaxBandTotal: 100},
+			dmarket.ItemsLimitPerRequest(100),
+		)
+		require.NoError(t, err)
+		var want int
+		for _, object := range catalog {
+			if object.Price.Usd >= 1000 && object.Price.Usd <= 5000 {
+				want++
+			}
+		}
+		require.Len(t, objects, want)
+	})
+	t.Run("error: stops on first error", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(common.MustReturnHTTPError(http.MethodGet, "/exchange/v1/market/items", http.StatusBadRequest))
+		defer ts.Close()
+		objects, err := dmarket.NewExchange(ts.Client).Items.Scan(context.Background(), dmarket.ScanParams{PriceTo: 1000})
+		require.Nil(t, objects)
+		require.ErrorAs(t, err, &dmarket.ErrorRepresentation{})
+	})
+}
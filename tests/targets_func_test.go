@@ -0,0 +1,71 @@
+package tests_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks"
+	"github.com/defernest/dmarket-go/mocks/common"
+	"github.com/defernest/dmarket-go/mocks/targets"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTargets(t *testing.T) {
+	store := targets.MustReturnSuccess()
+	ts := mocks.NewDmarketServer(store.Create(), store.List(), store.Delete())
+	defer ts.Close()
+	e := dmarket.NewExchange(ts.Client)
+
+	var create []dmarket.CreateTarget
+	for i := 0; i < 25; i++ {
+		create = append(create, dmarket.CreateTarget{
+			Title:  "★ Karambit | Doppler (Factory New)",
+			Amount: 1,
+			Price:  dmarket.MarketplacePrice{Currency: "USD", Amount: int64(50000 + i)},
+			Attrs:  dmarket.TargetAttributes{Phase: "phase-2", FloatPartValue: "0.00-0.01"},
+		})
+	}
+	create = append(create, dmarket.CreateTarget{Title: "no price", Amount: 1})
+	created, err := e.Targets.Create(context.Background(), "a8db", create...)
+	require.NoError(t, err)
+	require.Len(t, created.Result, 26)
+	require.Error(t, created.Err())
+	require.Equal(t, 25, store.Len())
+
+	page, err := e.Targets.List(context.Background(), dmarket.ListTargetsParams{GameID: "a8db", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 10)
+	require.Equal(t, int64(25), page.Total)
+	require.NotEmpty(t, page.Cursor)
+	require.Contains(t, page.Items[0].Attributes, dmarket.TargetAttribute{Name: "phase", Value: "phase-2"})
+
+	all, err := e.Targets.ListAll(context.Background(), dmarket.ListTargetsParams{GameID: "a8db", Status: dmarket.TargetStatusActive, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, all, 25)
+
+	ids := make([]string, 0, len(all))
+	for _, target := range all {
+		ids = append(ids, target.TargetID)
+	}
+	deleted, err := e.Targets.Delete(context.Background(), append(ids, "unknown")...)
+	require.NoError(t, err)
+	require.Len(t, deleted.Result, 26)
+	require.False(t, deleted.Result[25].Successful)
+	require.Zero(t, store.Len())
+}
+
+func TestTargets_Errors(t *testing.T) {
+	t.Run("error: empty batch", func(t *testing.T) {
+		_, err := dmarket.NewExchange(nil).Targets.Delete(context.Background())
+		require.ErrorIs(t, err, dmarket.ErrEmptyBatch)
+	})
+	t.Run("error: http error", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(common.MustReturnHTTPError(http.MethodGet, "/marketplace-api/v1/user-targets", http.StatusInternalServerError))
+		defer ts.Close()
+		_, err := dmarket.NewExchange(ts.Client).Targets.ListAll(context.Background(), dmarket.ListTargetsParams{GameID: "a8db"})
+		require.ErrorAs(t, err, &dmarket.ErrorRepresentation{})
+	})
+}
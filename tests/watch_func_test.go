@@ -0,0 +1,70 @@
+package tests_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/defernest/dmarket-go/dmarket"
+	"github.com/defernest/dmarket-go/mocks"
+	"github.com/defernest/dmarket-go/mocks/common"
+	"github.com/defernest/dmarket-go/mocks/items"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestItems_Watch(t *testing.T) {
+	t.Run("success: events of the evolved catalog", func(t *testing.T) {
+		first := items.Catalog(250, 100000)
+		second := items.Evolve(first, 5, 3, 4)
+		ts := mocks.NewDmarketServer(items.MustReturnSnapshots(first, second))
+		defer ts.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		events, err := dmarket.NewExchange(ts.Client).Items.Watch(ctx, 50*time.Millisecond, dmarket.ItemsLimitPerRequest(100))
+		require.NoError(t, err)
+
+		counts := make(map[dmarket.EventType]int)
+		for i := 0; i < 12; i++ {
+			event := <-events
+			require.NoError(t, event.Error)
+			counts[event.Type]++
+			switch event.Type {
+			case dmarket.EventPriceChanged:
+				require.Less(t, event.NewPrice.Usd, event.OldPrice.Usd)
+				require.Equal(t, event.NewPrice, event.Object.Price)
+			case dmarket.EventRemoved:
+				require.Equal(t, event.OldPrice, event.Object.Price)
+			case dmarket.EventAdded:
+				require.Equal(t, event.NewPrice, event.Object.Price)
+			}
+		}
+		require.Equal(t, map[dmarket.EventType]int{
+			dmarket.EventAdded:        4,
+			dmarket.EventRemoved:      5,
+			dmarket.EventPriceChanged: 3,
+		}, counts)
+
+		select {
+		case event := <-events:
+			t.Fatalf("unexpected event of the same snapshot: %+v", event)
+		case <-time.After(500 * time.Millisecond):
+		}
+		cancel()
+		require.Eventually(t, func() bool {
+			_, open := <-events
+			return !open
+		}, time.Second, 10*time.Millisecond)
+	})
+	t.Run("error: poll error is sent", func(t *testing.T) {
+		ts := mocks.NewDmarketServer(common.MustReturnHTTPError(http.MethodGet, "/exchange/v1/market/items", http.StatusInternalServerError))
+		defer ts.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events, err := dmarket.NewExchange(ts.Client).Items.Watch(ctx, 50*time.Millisecond)
+		require.NoError(t, err)
+		event := <-events
+		require.ErrorAs(t, event.Error, &dmarket.ErrorRepresentation{})
+	})
+}